@@ -0,0 +1,36 @@
+// Package check implements the report printed by the `check` command.
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"ls-projects/models/project"
+)
+
+// Report returns the report listing the problems found in the projects file at the given path,
+// and false if at least one of them prevents loading the file.
+func Report(path string, problems []project.Problem) (string, bool) {
+	if len(problems) == 0 {
+		return fmt.Sprintf("%s: no problem found\n", path), true
+	}
+
+	errorCount := 0
+	for _, p := range problems {
+		if !p.Warning {
+			errorCount++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %d error(s), %d warning(s)\n\n", path, errorCount, len(problems)-errorCount)
+	for _, p := range problems {
+		severity := "error"
+		if p.Warning {
+			severity = "warning"
+		}
+		fmt.Fprintf(&sb, "  %-8s %s\n", severity, p)
+	}
+
+	return sb.String(), errorCount == 0
+}
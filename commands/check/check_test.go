@@ -0,0 +1,60 @@
+package check
+
+import (
+	"testing"
+
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Report(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		problems []project.Problem
+
+		expectedReport string
+		expectedValid  bool
+	}{
+		{
+			testName: "no problem",
+			problems: nil,
+
+			expectedReport: "projects.json: no problem found\n",
+			expectedValid:  true,
+		},
+		{
+			testName: "warnings only",
+			problems: []project.Problem{
+				{Entry: "projects", Index: 1, Field: "path", Message: "directory/file ./api does not exist", Warning: true},
+			},
+
+			expectedReport: "projects.json: 0 error(s), 1 warning(s)\n\n" +
+				"  warning  projects[1].path: directory/file ./api does not exist\n",
+			expectedValid: true,
+		},
+		{
+			testName: "errors and warnings",
+			problems: []project.Problem{
+				{Entry: "projects", Index: 0, Field: "name", Message: "the field is required"},
+				{Entry: "projects", Index: 2, Field: "folders[0]", Message: "directory ./docs does not exist", Warning: true},
+				{Entry: "sets", Index: 0, Field: "name", Message: "the field is required"},
+			},
+
+			expectedReport: "projects.json: 2 error(s), 1 warning(s)\n\n" +
+				"  error    projects[0].name: the field is required\n" +
+				"  warning  projects[2].folders[0]: directory ./docs does not exist\n" +
+				"  error    sets[0].name: the field is required\n",
+			expectedValid: false,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			report, valid := Report("projects.json", testRun.problems)
+
+			assert.Equal(t, testRun.expectedReport, report)
+			assert.Equal(t, testRun.expectedValid, valid)
+		})
+	}
+}
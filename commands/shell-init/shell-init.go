@@ -0,0 +1,49 @@
+// Package shellinit implements the wrapper functions printed by the `shell-init` command.
+package shellinit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FunctionName is the name of the shell function changing the directory to the selected project.
+const FunctionName = "lsp"
+
+// Shells lists the supported shells.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// Script returns the wrapper function for the given shell, calling the binary with the `--print-path` flag
+// then changing the shell's directory to the printed path.
+// Returns an error if the shell is not supported.
+func Script(shell string, binary string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return fmt.Sprintf(`%s() {
+  local dir
+  dir="$(command %s --print-path "$@")" || return
+  [ -n "$dir" ] && cd -- "$dir"
+}
+`, FunctionName, posixQuote(binary)), nil
+
+	case "fish":
+		return fmt.Sprintf(`function %s
+    set -l dir (command %s --print-path $argv)
+    or return
+    test -n "$dir"; and cd -- $dir
+end
+`, FunctionName, fishQuote(binary)), nil
+	}
+
+	return "", fmt.Errorf("unsupported shell '%s', expected one of: %s", shell, strings.Join(Shells, ", "))
+}
+
+// posixQuote surrounds the string by single quotes, escaping the ones it contains.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote surrounds the string by single quotes, escaping the ones and the backslashes it contains.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
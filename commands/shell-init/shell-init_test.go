@@ -0,0 +1,67 @@
+package shellinit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Script(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		shell    string
+		binary   string
+
+		expectedSubstrings []string
+		expectErr          bool
+	}{
+		{
+			testName: "bash",
+			shell:    "bash",
+			binary:   "/usr/local/bin/ls-projects",
+
+			expectedSubstrings: []string{"lsp() {", `command '/usr/local/bin/ls-projects' --print-path "$@"`, `cd -- "$dir"`},
+			expectErr:          false,
+		},
+		{
+			testName: "zsh with quote in binary path",
+			shell:    "zsh",
+			binary:   "/home/o'neil/bin/ls-projects",
+
+			expectedSubstrings: []string{`command '/home/o'\''neil/bin/ls-projects' --print-path`},
+			expectErr:          false,
+		},
+		{
+			testName: "fish",
+			shell:    "fish",
+			binary:   "/home/o'neil/bin/ls-projects",
+
+			expectedSubstrings: []string{"function lsp", `command '/home/o\'neil/bin/ls-projects' --print-path $argv`, "end"},
+			expectErr:          false,
+		},
+		{
+			testName: "unsupported shell",
+			shell:    "powershell",
+			binary:   "ls-projects",
+
+			expectedSubstrings: nil,
+			expectErr:          true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			script, err := Script(testRun.shell, testRun.binary)
+
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+				assert.Empty(t, script)
+			} else {
+				assert.Nil(t, err)
+				for _, s := range testRun.expectedSubstrings {
+					assert.Contains(t, script, s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Package commandrunner implements a pane running commands and streaming their output.
+package commandrunner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"ls-projects/models/launcher"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	paneWidth  = 80
+	paneHeight = 15
+
+	// maxLineLength is the length of the longest output line read, longer lines being split
+	maxLineLength = 1024 * 1024
+)
+
+// lastID is the ID of the last started runner, used to ignore the messages of a runner closed before its command exited.
+var lastID = 0
+
+// A RunFunc runs commands writing their output to w, stopping them when the context is canceled.
+// The returned error is shown as the exit status.
+type RunFunc func(ctx context.Context, w io.Writer) error
+
+// command returns the RunFunc running the command.
+func command(cmd *exec.Cmd) RunFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		return launcher.Stream(ctx, cmd, w)
+	}
+}
+
+type Model struct {
+	id       int
+	title    string
+	command  string
+	run      RunFunc
+	ctx      context.Context
+	cancel   context.CancelFunc
+	events   chan tea.Msg
+	viewport viewport.Model
+	lines    []string
+	running  bool
+	err      error
+}
+
+// NewCommandRunner returns the pane running the command, titled with the given name.
+// The command starts when the command returned by Init is run.
+func NewCommandRunner(name string, cmd *exec.Cmd) Model {
+	m := NewFuncRunner(name, command(cmd))
+	m.command = strings.Join(cmd.Args, " ")
+	return m
+}
+
+// NewFuncRunner returns the pane streaming the output of the commands run by the function, titled with the given name.
+// The function is called when the command returned by Init is run.
+func NewFuncRunner(name string, run RunFunc) Model {
+	lastID++
+	ctx, cancel := context.WithCancel(context.Background())
+	return Model{
+		id:       lastID,
+		title:    name,
+		run:      run,
+		ctx:      ctx,
+		cancel:   cancel,
+		events:   make(chan tea.Msg),
+		viewport: viewport.New(paneWidth, paneHeight),
+		running:  true,
+	}
+}
+
+// Init calls the run function, the output being streamed through OutputMsg messages until an ExitMsg is sent.
+func (m Model) Init() tea.Cmd {
+	id, ctx, run, events := m.id, m.ctx, m.run, m.events
+
+	start := func() tea.Msg {
+		r, w := io.Pipe()
+
+		exited := make(chan error, 1)
+		go func() {
+			err := run(ctx, w)
+			w.Close()
+			exited <- err
+		}()
+
+		go func() {
+			scanner := bufio.NewScanner(r)
+			scanner.Buffer(nil, maxLineLength)
+			for scanner.Scan() {
+				events <- OutputMsg{id, scanner.Text()}
+			}
+			// drain what's left if the scanner stopped early so the command isn't blocked writing
+			io.Copy(io.Discard, r)
+			events <- ExitMsg{id, <-exited}
+			close(events)
+		}()
+
+		return <-events
+	}
+
+	return start
+}
+
+// waitForEvent returns the command waiting for the next output line or the exit of the command.
+func (m Model) waitForEvent() tea.Cmd {
+	return func() tea.Msg { return <-m.events }
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OutputMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		follow := m.viewport.AtBottom()
+		m.lines = append(m.lines, msg.Line)
+		m.viewport.SetContent(strings.Join(m.lines, "\n"))
+		if follow {
+			m.viewport.GotoBottom()
+		}
+		return m, m.waitForEvent()
+
+	case ExitMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+
+		m.running = false
+		m.err = msg.Err
+		m.cancel()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.viewport.Width = min(msg.Width-4, paneWidth)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.stop()
+			return m, func() tea.Msg { return CloseRunner{} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// stop stops the commands if they are still running, discarding their remaining output.
+func (m *Model) stop() {
+	if m.running {
+		m.cancel()
+		go func() {
+			for range m.events {
+			}
+		}()
+	}
+}
+
+// Running returns true until the command exited.
+func (m Model) Running() bool {
+	return m.running
+}
+
+// Err returns the error of the command once it exited, nil if it succeeded.
+func (m Model) Err() error {
+	return m.err
+}
+
+// Output returns the lines written by the command so far.
+func (m Model) Output() []string {
+	return m.lines
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	var title string
+	switch {
+	case m.running:
+		title = Style.RunningTitleStyle.Render(fmt.Sprintf("running '%s'...", m.title))
+	case m.err != nil:
+		title = Style.ErrorTitleStyle.Render(fmt.Sprintf("'%s' failed", m.title))
+	default:
+		title = Style.SuccessTitleStyle.Render(fmt.Sprintf("'%s' succeeded", m.title))
+	}
+	fmt.Fprintf(&b, "\n%s\n", title)
+	if m.command != "" {
+		fmt.Fprintf(&b, "%s\n", Style.CommandStyle.Render("$ "+m.command))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.viewport.View())
+
+	if m.err != nil {
+		fmt.Fprintf(&b, "\n\n%s", Style.ExitStatusStyle.Render(exitStatus(m.err)))
+	}
+
+	help := "↑/↓ scroll • esc close"
+	if m.running {
+		help = "↑/↓ scroll • esc stop and close"
+	}
+	fmt.Fprintf(&b, "\n\n%s", Style.HelpStyle.Render(help))
+
+	return Style.MarginStyle.Render(b.String())
+}
+
+// exitStatus returns the exit code of the failed command, or the reason why it didn't start.
+func exitStatus(err error) string {
+	var launcherErr *launcher.Error
+	if errors.As(err, &launcherErr) && launcherErr.ExitCode >= 0 {
+		return fmt.Sprintf("exit status %d", launcherErr.ExitCode)
+	}
+	return err.Error()
+}
@@ -0,0 +1,16 @@
+package commandrunner
+
+// CloseRunner is sent when the output pane is closed, after stopping the command if it was still running.
+type CloseRunner struct{}
+
+// OutputMsg holds a line written by the command on stdout or stderr.
+type OutputMsg struct {
+	id   int
+	Line string
+}
+
+// ExitMsg is sent once the command exited, Err being nil if it succeeded.
+type ExitMsg struct {
+	id  int
+	Err error
+}
@@ -0,0 +1,27 @@
+package commandrunner
+
+import (
+	"ls-projects/components/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type CommandRunnerStyles struct {
+	RunningTitleStyle lipgloss.Style
+	SuccessTitleStyle lipgloss.Style
+	ErrorTitleStyle   lipgloss.Style
+	CommandStyle      lipgloss.Style
+	ExitStatusStyle   lipgloss.Style
+	HelpStyle         lipgloss.Style
+	MarginStyle       lipgloss.Style
+}
+
+var Style = CommandRunnerStyles{
+	RunningTitleStyle: styles.BaseTitle().MarginLeft(0).Background(lipgloss.Color("#4d4d4d")),
+	SuccessTitleStyle: styles.BaseTitle().MarginLeft(0).Background(lipgloss.Color("#25A065")),
+	ErrorTitleStyle:   styles.BaseTitle().MarginLeft(0).Background(lipgloss.Color("#E84855")),
+	CommandStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	ExitStatusStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#E84855")),
+	HelpStyle:         lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true).Faint(true),
+	MarginStyle:       lipgloss.NewStyle().MarginLeft(4),
+}
@@ -0,0 +1,6 @@
+package picker
+
+type CancelPick struct{}
+type SubmitPick struct {
+	Index int
+}
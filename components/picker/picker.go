@@ -0,0 +1,138 @@
+package picker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// An Item is an entry of the picker.
+type Item struct {
+	Title       string
+	Description string
+}
+
+// maxVisibleItems is the number of items shown at once, the items scrolling to keep the cursor visible.
+const maxVisibleItems = 10
+
+type Model struct {
+	title  string
+	items  []Item
+	cursor int
+
+	// index of the first visible item
+	offset int
+
+	// error shown under the title, e.g. when some items couldn't be listed
+	err error
+}
+
+func NewPicker(title string, items []Item) Model {
+	return Model{
+		title: title,
+		items: items,
+	}
+}
+
+// SetError sets the error shown under the title, nil hiding it.
+func (m *Model) SetError(err error) {
+	m.err = err
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch keypress := msg.String(); keypress {
+		case "ctrl+c", "esc", "q":
+			return m, func() tea.Msg { return CancelPick{} }
+
+		case "enter", "space":
+			if len(m.items) == 0 {
+				return m, nil
+			}
+			index := m.cursor
+			return m, func() tea.Msg { return SubmitPick{index} }
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+
+		case "pgup":
+			m.cursor = max(m.cursor-maxVisibleItems, 0)
+
+		case "pgdown":
+			m.cursor = max(min(m.cursor+maxVisibleItems, len(m.items)-1), 0)
+
+		default:
+			// number keys pick the matching item directly
+			if n, err := strconv.Atoi(keypress); err == nil && n >= 1 && n <= len(m.items) {
+				return m, func() tea.Msg { return SubmitPick{n - 1} }
+			}
+		}
+	}
+
+	m.scrollToCursor()
+	return m, nil
+}
+
+// scrollToCursor moves the visible items so the cursor stays among them.
+func (m *Model) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+maxVisibleItems {
+		m.offset = m.cursor - maxVisibleItems + 1
+	}
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "\n%s\n\n", Style.TitleStyle.Render(m.title))
+	if m.err != nil {
+		fmt.Fprintf(&b, "%s\n\n", Style.ErrorStyle.Render(m.err.Error()))
+	}
+
+	end := min(m.offset+maxVisibleItems, len(m.items))
+	if m.offset > 0 {
+		fmt.Fprintf(&b, "%s\n", Style.HelpStyle.Render(fmt.Sprintf("↑ %d more", m.offset)))
+	}
+
+	for i := m.offset; i < end; i++ {
+		item := m.items[i]
+		str := fmt.Sprintf("%d. %s", i+1, item.Title)
+		if item.Description != "" {
+			str += " " + Style.DescriptionStyle.Render(item.Description)
+		}
+
+		if i == m.cursor {
+			b.WriteString(Style.SelectedItemStyle.Render("> " + str))
+		} else {
+			b.WriteString(Style.ItemStyle.Render(str))
+		}
+		b.WriteRune('\n')
+	}
+
+	if end < len(m.items) {
+		fmt.Fprintf(&b, "%s\n", Style.HelpStyle.Render(fmt.Sprintf("↓ %d more", len(m.items)-end)))
+	}
+
+	help := "⏎ select • esc cancel"
+	if len(m.items) > maxVisibleItems {
+		help += " • pgup/pgdown scroll"
+	}
+	fmt.Fprintf(&b, "\n%s", Style.HelpStyle.Render(help))
+
+	return Style.MarginStyle.Render(b.String())
+}
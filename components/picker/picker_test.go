@@ -0,0 +1,115 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Scrolling(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		keys     []tea.KeyType
+
+		expectedCursor  int
+		expectedVisible []int
+		expectedAbove   string
+		expectedBelow   string
+	}{
+		{
+			testName: "first items shown initially",
+			keys:     nil,
+
+			expectedCursor:  0,
+			expectedVisible: []int{1, 10},
+			expectedAbove:   "",
+			expectedBelow:   "↓ 15 more",
+		},
+		{
+			testName: "scrolled down past the last visible item",
+			keys:     repeat(tea.KeyDown, 12),
+
+			expectedCursor:  12,
+			expectedVisible: []int{4, 13},
+			expectedAbove:   "↑ 3 more",
+			expectedBelow:   "↓ 12 more",
+		},
+		{
+			testName: "scrolled back up",
+			keys:     append(repeat(tea.KeyDown, 12), repeat(tea.KeyUp, 10)...),
+
+			expectedCursor:  2,
+			expectedVisible: []int{3, 12},
+			expectedAbove:   "↑ 2 more",
+			expectedBelow:   "↓ 13 more",
+		},
+		{
+			testName: "paged down to the last item",
+			keys:     repeat(tea.KeyPgDown, 3),
+
+			expectedCursor:  24,
+			expectedVisible: []int{16, 25},
+			expectedAbove:   "↑ 15 more",
+			expectedBelow:   "",
+		},
+		{
+			testName: "paged up to the first item",
+			keys:     []tea.KeyType{tea.KeyPgDown, tea.KeyPgDown, tea.KeyPgUp, tea.KeyPgUp, tea.KeyPgUp},
+
+			expectedCursor:  0,
+			expectedVisible: []int{1, 10},
+			expectedAbove:   "",
+			expectedBelow:   "↓ 15 more",
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			items := make([]Item, 25)
+			for i := range items {
+				items[i] = Item{Title: fmt.Sprintf("item-%d", i+1)}
+			}
+
+			var m tea.Model = NewPicker("Run in 'api'...", items)
+			for _, key := range testRun.keys {
+				m, _ = m.Update(tea.KeyMsg{Type: key})
+			}
+
+			assert.Equal(t, testRun.expectedCursor, m.(Model).cursor)
+
+			view := m.View()
+			var visible []int
+			for _, line := range strings.Split(view, "\n") {
+				var n int
+				if _, err := fmt.Sscanf(strings.TrimPrefix(strings.TrimSpace(line), "> "), "%d.", &n); err == nil {
+					visible = append(visible, n)
+				}
+			}
+			assert.Equal(t, testRun.expectedVisible[0], visible[0])
+			assert.Equal(t, testRun.expectedVisible[1], visible[len(visible)-1])
+			assert.Len(t, visible, testRun.expectedVisible[1]-testRun.expectedVisible[0]+1)
+			assert.Contains(t, view, fmt.Sprintf("> %d. item-%d", testRun.expectedCursor+1, testRun.expectedCursor+1))
+
+			assert.Equal(t, testRun.expectedAbove != "", strings.Contains(view, "↑"))
+			if testRun.expectedAbove != "" {
+				assert.Contains(t, view, testRun.expectedAbove)
+			}
+			assert.Equal(t, testRun.expectedBelow != "", strings.Contains(view, "↓"))
+			if testRun.expectedBelow != "" {
+				assert.Contains(t, view, testRun.expectedBelow)
+			}
+		})
+	}
+}
+
+// repeat returns the key repeated n times.
+func repeat(key tea.KeyType, n int) []tea.KeyType {
+	keys := make([]tea.KeyType, n)
+	for i := range keys {
+		keys[i] = key
+	}
+	return keys
+}
@@ -0,0 +1,27 @@
+package picker
+
+import (
+	"ls-projects/components/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type PickerStyles struct {
+	TitleStyle        lipgloss.Style
+	ItemStyle         lipgloss.Style
+	SelectedItemStyle lipgloss.Style
+	DescriptionStyle  lipgloss.Style
+	ErrorStyle        lipgloss.Style
+	HelpStyle         lipgloss.Style
+	MarginStyle       lipgloss.Style
+}
+
+var Style = PickerStyles{
+	TitleStyle:        styles.BaseTitle().MarginLeft(0).Background(lipgloss.Color("#6C91BF")),
+	ItemStyle:         lipgloss.NewStyle().PaddingLeft(2),
+	SelectedItemStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#6C91BF")),
+	DescriptionStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	ErrorStyle:        lipgloss.NewStyle().Foreground(lipgloss.Color("#E84855")),
+	HelpStyle:         lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true).Faint(true),
+	MarginStyle:       lipgloss.NewStyle().MarginLeft(4),
+}
@@ -3,6 +3,7 @@ package projectform
 import (
 	"errors"
 	"ls-projects/models/project"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -19,27 +20,51 @@ func handleFormKeybinds(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "tab", "shift+tab", "enter", "up", "down":
 		s := msg.String()
 
+		// The description and environment variables span multiple lines, let them handle enter and arrows themselves.
+		if (m.focusIndex == m.descriptionIndex() || m.focusIndex == m.envIndex()) && s != "tab" && s != "shift+tab" {
+			return nil, nil
+		}
+
 		// Did the user press enter or space while the submit button was focused?
 		// If so, create the project and exit to the list-selector component.
-		if (s == "enter" || s == "space") && m.focusIndex == len(m.inputs) {
+		if (s == "enter" || s == "space") && m.focusIndex == m.submitIndex() {
 			for i := range m.inputs {
+				if m.inputs[i].Validate == nil {
+					continue
+				}
+
 				err := m.inputs[i].Validate(m.inputs[i].Value())
 				if err != nil {
 					return m.Update(ProjectCreationErrorMsg(err))
 				}
 			}
 
-			p := &project.Project{
-				Name: m.inputs[0].Value(),
-				Path: m.inputs[1].Value(),
+			p := m.project
+			p.Name = m.inputs[0].Value()
+			p.Path = m.inputs[1].Value()
+			p.Launcher = m.launcher()
+			p.Folders = splitList(m.inputs[3].Value())
+			p.Host = strings.TrimSpace(m.inputs[4].Value())
+			p.Tags = splitList(m.inputs[5].Value())
+			p.Group = project.NormalizeGroup(m.inputs[6].Value())
+			p.Description = strings.TrimSpace(m.description.Value())
+
+			env, err := parseEnv(m.env.Value())
+			if err != nil {
+				return m.Update(ProjectCreationErrorMsg(err))
 			}
+			p.Env = env
 
 			if valid := p.ValidatePath(); valid {
+				if err := m.checkDuplicates(&p); err != nil {
+					return m.Update(ProjectCreationErrorMsg(err))
+				}
+
 				var msg tea.Msg
 				if m.isEditMode {
-					msg = ProjectUpdatedMsg{*p}
+					msg = ProjectUpdatedMsg{p}
 				} else {
-					msg = ProjectCreatedMsg{*p}
+					msg = ProjectCreatedMsg{p}
 				}
 				return m.Model.Update(msg)
 			} else {
@@ -54,10 +79,10 @@ func handleFormKeybinds(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.focusIndex++
 		}
 
-		if m.focusIndex > len(m.inputs) {
+		if m.focusIndex > m.submitIndex() {
 			m.focusIndex = 0
 		} else if m.focusIndex < 0 {
-			m.focusIndex = len(m.inputs)
+			m.focusIndex = m.submitIndex()
 		}
 
 		cmds := make([]tea.Cmd, len(m.inputs))
@@ -74,6 +99,18 @@ func handleFormKeybinds(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.inputs[i].TextStyle = Style.NoStyle
 		}
 
+		if m.focusIndex == m.descriptionIndex() {
+			cmds = append(cmds, m.description.Focus())
+		} else {
+			m.description.Blur()
+		}
+
+		if m.focusIndex == m.envIndex() {
+			cmds = append(cmds, m.env.Focus())
+		} else {
+			m.env.Blur()
+		}
+
 		return m, tea.Batch(cmds...)
 	}
 
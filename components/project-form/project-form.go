@@ -3,9 +3,12 @@ package projectform
 import (
 	"errors"
 	"fmt"
+	"ls-projects/models/config"
 	"ls-projects/models/project"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -24,18 +27,27 @@ func focusedButton(m Model) string {
 }
 
 type Model struct {
-	focusIndex int
-	inputs     []textinput.Model
-	Model      tea.Model
-	isEditMode bool
-	err        error
+	focusIndex  int
+	inputs      []textinput.Model
+	description textarea.Model
+	env         textarea.Model
+	Model       tea.Model
+	isEditMode  bool
+	project     project.Project
+	err         error
+
+	// path the user was warned to be already used by another project, submitting it again saves the project anyway
+	duplicatePath string
 }
 
-func NewProjectForm(l tea.Model, project *project.Project) Model {
+func NewProjectForm(l tea.Model, p *project.Project) Model {
 	m := Model{
-		inputs:     make([]textinput.Model, 2),
+		inputs:     make([]textinput.Model, 7),
 		Model:      l,
-		isEditMode: project != nil,
+		isEditMode: p != nil,
+	}
+	if m.isEditMode {
+		m.project = *p
 	}
 
 	var t textinput.Model
@@ -52,23 +64,80 @@ func NewProjectForm(l tea.Model, project *project.Project) Model {
 			t.TextStyle = focusedStyle(m)
 			t.Validate = validateTextField
 			if m.isEditMode {
-				t.SetValue(project.Name)
+				t.SetValue(p.Name)
 			}
 
 		case 1:
-			t.Placeholder = "Path [*]"
+			t.Placeholder = "Path or .code-workspace file [*]"
 			t.Validate = validateTextField
 			if m.isEditMode {
-				t.SetValue(project.Path)
+				t.SetValue(p.Path)
+			}
+
+		case 2:
+			t.Placeholder = "Launcher command, e.g. zed {path}"
+			if m.isEditMode && p.Launcher != nil {
+				t.SetValue(p.Launcher.Command)
+			}
+
+		case 3:
+			t.Placeholder = "Additional workspace folders, comma separated"
+			if m.isEditMode {
+				t.SetValue(strings.Join(p.Folders, ", "))
+			}
+
+		case 4:
+			t.Placeholder = "Remote SSH host"
+			if m.isEditMode {
+				t.SetValue(p.Host)
+			}
+
+		case 5:
+			t.Placeholder = "Tags, comma separated"
+			if m.isEditMode {
+				t.SetValue(strings.Join(p.Tags, ", "))
+			}
+
+		case 6:
+			t.Placeholder = "Group, nested with slashes, e.g. Work/Client A"
+			if m.isEditMode {
+				t.SetValue(p.Group)
 			}
 		}
 
 		m.inputs[i] = t
 	}
 
+	m.description = newTextArea(m, "Description")
+	m.env = newTextArea(m, "Environment variables, one KEY=VALUE per line")
+	if m.isEditMode {
+		m.description.SetValue(p.Description)
+		m.env.SetValue(formatEnv(p.Env))
+	}
+
 	return m
 }
 
+// newTextArea returns a multi-line input, focused after every text input.
+func newTextArea(m Model, placeholder string) textarea.Model {
+	t := textarea.New()
+	t.Placeholder = placeholder
+	t.Prompt = "> "
+	t.ShowLineNumbers = false
+	t.CharLimit = 0
+	t.SetWidth(60)
+	t.SetHeight(3)
+	t.Cursor.Style = focusedStyle(m)
+
+	t.FocusedStyle, t.BlurredStyle = textarea.DefaultStyles()
+	t.FocusedStyle.CursorLine = Style.NoStyle
+	t.FocusedStyle.Prompt = focusedStyle(m)
+	t.FocusedStyle.Text = focusedStyle(m)
+	t.BlurredStyle.Prompt = Style.NoStyle
+	t.BlurredStyle.Text = Style.NoStyle
+	return t
+}
+
 func (m Model) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -78,6 +147,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ProjectCreationErrorMsg:
 		m.err = msg
 
+	case ProjectUpdateErrorMsg:
+		m.err = msg
+
 	case tea.KeyMsg:
 		tmpModel, tempCmd := handleFormKeybinds(&m, msg)
 		if tmpModel != nil {
@@ -92,17 +164,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateInputs(msg tea.Msg) tea.Cmd {
-	var cmds = make([]tea.Cmd, len(m.inputs))
+	var cmds = make([]tea.Cmd, len(m.inputs)+2)
 
 	// Only text inputs with Focus() set will respond, so it's safe to simply
 	// update all of them here without any further logic.
 	for i := range m.inputs {
 		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
 	}
+	m.description, cmds[len(m.inputs)] = m.description.Update(msg)
+	m.env, cmds[len(m.inputs)+1] = m.env.Update(msg)
 
 	return tea.Batch(cmds...)
 }
 
+// checkDuplicates returns an error if another project has the same name as the project.
+// If another project has the same path, it returns a warning the first time the path is submitted only, the project then allowing the duplicate path.
+func (m *Model) checkDuplicates(p *project.Project) error {
+	err := project.CheckDuplicates(*p)
+
+	var duplicateErr *project.DuplicateError
+	if errors.As(err, &duplicateErr) && duplicateErr.Field == "path" {
+		if m.duplicatePath == p.Path {
+			p.AllowDuplicatePath = true
+			return nil
+		}
+		m.duplicatePath = p.Path
+		return fmt.Errorf("%s, submit again to save anyway", err)
+	}
+	return err
+}
+
+// descriptionIndex returns the focus index of the description input.
+func (m Model) descriptionIndex() int {
+	return len(m.inputs)
+}
+
+// envIndex returns the focus index of the environment variables input.
+func (m Model) envIndex() int {
+	return len(m.inputs) + 1
+}
+
+// submitIndex returns the focus index of the submit button.
+func (m Model) submitIndex() int {
+	return len(m.inputs) + 2
+}
+
 func (m Model) View() string {
 	var b strings.Builder
 
@@ -122,13 +228,14 @@ func (m Model) View() string {
 
 	for i := range m.inputs {
 		b.WriteString(m.inputs[i].View())
-		if i < len(m.inputs)-1 {
-			b.WriteRune('\n')
-		}
+		b.WriteRune('\n')
 	}
+	b.WriteString(m.description.View())
+	b.WriteRune('\n')
+	b.WriteString(m.env.View())
 
 	button := Style.BlurredButton()
-	if m.focusIndex == len(m.inputs) {
+	if m.focusIndex == m.submitIndex() {
 		button = focusedButton(m)
 	}
 	fmt.Fprintf(&b, "\n\n%s\n\n", button)
@@ -138,6 +245,66 @@ func (m Model) View() string {
 	return Style.MarginStyle.Render(b.String())
 }
 
+// launcher returns the project's launcher override built from the launcher input.
+// Returns nil if the resulting launcher is empty so the global launcher is used.
+func (m Model) launcher() *config.Launcher {
+	l := config.Launcher{RunInProjectDir: true}
+	if m.project.Launcher != nil {
+		l = *m.project.Launcher
+	}
+
+	l.Command = strings.TrimSpace(m.inputs[2].Value())
+	if l.IsEmpty() {
+		return nil
+	}
+	return &l
+}
+
+// splitList returns the trimmed and non-empty values of a comma separated input.
+func splitList(v string) []string {
+	var values []string
+	for _, value := range strings.Split(v, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// formatEnv returns the environment variables as KEY=VALUE lines, sorted by key.
+func formatEnv(env map[string]string) string {
+	lines := make([]string, 0, len(env))
+	for key, value := range env {
+		lines = append(lines, key+"="+value)
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n")
+}
+
+// parseEnv returns the environment variables of the KEY=VALUE lines, ignoring empty lines.
+// Returns an error if a line has no key or the key contains spaces.
+func parseEnv(v string) (map[string]string, error) {
+	var env map[string]string
+	for _, line := range strings.Split(v, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid environment variable '%s', expected KEY=VALUE", line)
+		}
+
+		if env == nil {
+			env = map[string]string{}
+		}
+		env[key] = strings.TrimSpace(value)
+	}
+	return env, nil
+}
+
 func validateTextField(v string) error {
 	if v == "" {
 		return errors.New("fields can't be empty")
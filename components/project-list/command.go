@@ -0,0 +1,72 @@
+package projectlist
+
+import (
+	"fmt"
+	"slices"
+
+	commandrunner "ls-projects/components/command-runner"
+	"ls-projects/components/picker"
+	"ls-projects/models/launcher"
+	"ls-projects/models/project"
+	"ls-projects/models/tasks"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// detectTasks returns the command detecting the tasks defined in the project's directory, the picker being shown once they are found.
+// Tasks are not detected for remote projects since their directory is not on the host.
+func detectTasks(p project.Project) tea.Cmd {
+	return func() tea.Msg {
+		if p.IsRemote() {
+			return tasksDetectedMsg{projectID: p.ID}
+		}
+
+		targets, err := tasks.Detect(launcher.ProjectDir(p))
+		return tasksDetectedMsg{p.ID, targets, err}
+	}
+}
+
+// openCommandMenu shows the picker listing the commands of the project followed by its detected tasks.
+// The error of the task files that couldn't be read is shown in the picker, or in the title if there is nothing to pick.
+func (m *Model) openCommandMenu(p project.Project, targets []tasks.Target, tasksErr error) tea.Cmd {
+	m.commands = slices.Clone(p.Commands)
+	for _, t := range targets {
+		m.commands = append(m.commands, project.Command{Name: t.Name, Run: t.Command()})
+	}
+
+	if len(m.commands) == 0 {
+		m.commands = nil
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("project '%s' has no command nor task", p.Name)
+		if tasksErr != nil {
+			m.list.Title = firstLine(tasksErr.Error())
+		}
+		return nil
+	}
+
+	items := make([]picker.Item, len(m.commands))
+	for i, c := range m.commands {
+		items[i] = picker.Item{Title: c.Name, Description: c.Run}
+	}
+
+	menu := picker.NewPicker(fmt.Sprintf("Run in '%s'...", p.Name), items)
+	menu.SetError(tasksErr)
+	m.actionMenu = &menu
+
+	return nil
+}
+
+// runCommand runs the command from the project's directory, showing its output in the runner pane.
+func (m *Model) runCommand(p project.Project, c project.Command) tea.Cmd {
+	runner := commandrunner.NewCommandRunner(c.Name, launcher.Shell(p, c.Run))
+	m.runner = &runner
+	return m.runner.Init()
+}
+
+// updateRunner forwards the message to the runner pane.
+func (m *Model) updateRunner(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.runner.Update(msg)
+	runner := model.(commandrunner.Model)
+	m.runner = &runner
+	return m, cmd
+}
@@ -0,0 +1,27 @@
+package projectlist
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askConfirmation shows the question in the list's title, the action running once y is pressed.
+// Any other key cancels the action.
+func (m *Model) askConfirmation(question string, action func(m *Model) tea.Cmd) {
+	m.confirmed = action
+
+	m.list.Styles.Title = Style.ErrorTitleStyle
+	m.list.Title = question + " (y/n)"
+}
+
+// handleConfirmation handles the key messages while a confirmation is asked.
+func (m *Model) handleConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action := m.confirmed
+	m.confirmed = nil
+
+	if msg.String() == "y" {
+		return m, action(m)
+	}
+
+	resetListTitle(m)
+	return m, nil
+}
@@ -0,0 +1,144 @@
+package projectlist
+
+import (
+	"strings"
+	"sync"
+
+	"ls-projects/models/config"
+	"ls-projects/models/launcher"
+	"ls-projects/models/project"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// changeHooks returns the command lines of the change hooks defined in the config.
+var changeHooks = func() []string {
+	if hooks := config.GetInstance().Hooks; hooks != nil {
+		return hooks.OnChange
+	}
+	return nil
+}
+
+// changes runs the change hooks of every mutation, in the order the mutations were made.
+var changes = &changeQueue{}
+
+// changeBatch holds the events of a single mutation and the channel receiving the outcome of their hooks.
+type changeBatch struct {
+	commandLines []string
+	events       []launcher.Event
+	done         chan error
+}
+
+// changeQueue runs the change hooks of the pushed batches one at a time, in a single background goroutine.
+type changeQueue struct {
+	mu      sync.Mutex
+	pending []changeBatch
+
+	// closed once the goroutine running the hooks stopped, nil if it was never started
+	idle chan struct{}
+}
+
+// push queues the hooks' command lines to be run for the events after the ones already queued.
+// The returned channel receives the hooks' error once they ran.
+func (q *changeQueue) push(commandLines []string, events []launcher.Event) <-chan error {
+	b := changeBatch{commandLines, events, make(chan error, 1)}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, b)
+	if !q.running() {
+		q.idle = make(chan struct{})
+		go q.run(q.idle)
+	}
+	return b.done
+}
+
+// running returns true while the goroutine running the hooks hasn't stopped, q.mu must be held.
+func (q *changeQueue) running() bool {
+	if q.idle == nil {
+		return false
+	}
+	select {
+	case <-q.idle:
+		return false
+	default:
+		return true
+	}
+}
+
+// run runs the hooks of the pending batches until none is left, then closes idle.
+func (q *changeQueue) run(idle chan struct{}) {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			close(idle)
+			q.mu.Unlock()
+			return
+		}
+		b := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		b.done <- launcher.RunChangeHooks(b.commandLines, b.events...)
+	}
+}
+
+// wait blocks until the hooks of every pushed batch ran.
+func (q *changeQueue) wait() {
+	q.mu.Lock()
+	idle := q.idle
+	q.mu.Unlock()
+
+	if idle != nil {
+		<-idle
+	}
+}
+
+// notifyChange queues the change hooks of the config for each event, returning the command waiting for them, nil if none is defined.
+// The hooks run in the background since the change is already saved, a failure only being reported in the list's title.
+func notifyChange(events ...launcher.Event) tea.Cmd {
+	commandLines := changeHooks()
+	if len(commandLines) == 0 || len(events) == 0 {
+		return nil
+	}
+
+	done := changes.push(commandLines, events)
+	return func() tea.Msg {
+		if err := <-done; err != nil {
+			return changeHookErrorMsg{err}
+		}
+		return nil
+	}
+}
+
+// quit waits for the queued change hooks to run, so quitting right after a change doesn't drop its notification, then quits the app.
+func quit() tea.Msg {
+	changes.wait()
+	return tea.QuitMsg{}
+}
+
+// changeEvents returns an event of the given type for each project.
+func changeEvents(eventType string, projects []project.Project) []launcher.Event {
+	events := make([]launcher.Event, len(projects))
+	for i, p := range projects {
+		events[i] = launcher.Event{Type: eventType, Project: p}
+	}
+	return events
+}
+
+// projectNamed returns the project with the given name, false if not found.
+func projectNamed(projects []project.Project, name string) (project.Project, bool) {
+	for _, p := range projects {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return project.Project{}, false
+}
+
+// firstLine returns the first line of s, e.g. the first of several joined errors.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
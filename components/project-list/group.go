@@ -0,0 +1,62 @@
+package projectlist
+
+import (
+	"fmt"
+
+	"ls-projects/models/launcher"
+	"ls-projects/models/project"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// groupHeader is the list item heading the projects of a group.
+type groupHeader struct {
+	project.Group
+
+	// number of projects in the group and its subgroups
+	count int
+}
+
+// implements interface list.Item for type groupHeader
+func (h groupHeader) FilterValue() string {
+	return h.Name
+}
+
+// toggleGroup collapses the group if it is expanded, expands it otherwise.
+func (m *Model) toggleGroup(h groupHeader) tea.Cmd {
+	groups, err := project.SetGroupCollapsed(h.Name, !h.Collapsed)
+	if err != nil {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("error toggling group '%s'", h.Name)
+		return nil
+	}
+
+	m.groups = groups
+	return m.refreshItems()
+}
+
+// moveToGroup moves the project being moved into the group, then disables the moving mode.
+func (m *Model) moveToGroup(h groupHeader) tea.Cmd {
+	p, ok := m.projectByID(m.movedProjectID)
+	if !ok {
+		return nil
+	}
+
+	projects, err := project.MoveToGroup(p.ID, h.Name)
+	if err != nil {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("error moving project '%s'", p.Name)
+		return nil
+	}
+
+	cmd := m.setProjects(projects)
+	disableMovingMode(m)
+
+	m.list.Styles.Title = Style.SuccessTitleStyle
+	m.list.Title = fmt.Sprintf("project '%s' moved to '%s'", p.Name, h.Name)
+
+	if moved, ok := m.projectByID(p.ID); ok {
+		return tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectUpdated, Project: moved}))
+	}
+	return cmd
+}
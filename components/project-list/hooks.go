@@ -0,0 +1,125 @@
+package projectlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	commandrunner "ls-projects/components/command-runner"
+	"ls-projects/models/config"
+	"ls-projects/models/launcher"
+	"ls-projects/models/project"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// opening holds the outcome of opening projects with hooks, filled while the hooks run.
+type opening struct {
+	// projects opened so far
+	opened []project.Project
+
+	// foreground command returned by the last launcher needing the terminal
+	foreground *exec.Cmd
+
+	// whether a hook failed with the warn policy
+	warned bool
+
+	// closed once the hooks and launchers stopped, the fields above being final
+	done chan struct{}
+}
+
+// wait returns the command waiting for the hooks and launchers to stop, e.g. once canceled by closing the pane.
+func (o *opening) wait() tea.Cmd {
+	return func() tea.Msg {
+		<-o.done
+		return openingStoppedMsg{}
+	}
+}
+
+// hasHooks returns true if hooks are defined for at least one of the projects.
+func hasHooks(projects []project.Project) bool {
+	for _, p := range projects {
+		if !launcher.ResolveHooks(config.GetInstance().Hooks, p).IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// openWithHooks opens the projects like openProjects, running their pre-open and post-open hooks around each launch.
+// The output of the hooks is streamed into the runner pane, which stays open if a hook failed so the failure can be read.
+// Once the pane is closed, the app quits if at least one project was opened.
+func (m *Model) openWithHooks(projects []project.Project, launchers []config.Launcher) tea.Cmd {
+	o := &opening{done: make(chan struct{})}
+	run := func(ctx context.Context, w io.Writer) error {
+		defer close(o.done)
+
+		for i, p := range projects {
+			hooks := launcher.ResolveHooks(config.GetInstance().Hooks, p)
+
+			if err := launcher.RunHooks(ctx, p, hooks.PreOpen, hooks.Policy(), w); err != nil {
+				if hooks.Policy() == config.AbortOnFailure || ctx.Err() != nil {
+					return fmt.Errorf("pre-open hook of '%s' failed: %w", p.Name, err)
+				}
+				o.warned = true
+			}
+
+			// the pane was closed while the hooks ran
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "opening '%s'...\n", p.Name)
+			cmd, err := launcher.Open(launchers[i], p)
+			if err != nil {
+				return err
+			}
+			if cmd != nil {
+				o.foreground = cmd
+			}
+			o.opened = append(o.opened, p)
+
+			if err := launcher.RunHooks(ctx, p, hooks.PostOpen, hooks.Policy(), w); err != nil {
+				if hooks.Policy() == config.AbortOnFailure || ctx.Err() != nil {
+					return fmt.Errorf("post-open hook of '%s' failed: %w", p.Name, err)
+				}
+				o.warned = true
+			}
+		}
+		return nil
+	}
+
+	names := make([]string, len(projects))
+	for i, p := range projects {
+		names[i] = p.Name
+	}
+
+	m.opening = o
+	runner := commandrunner.NewFuncRunner("open "+strings.Join(names, ", "), run)
+	m.runner = &runner
+	return m.runner.Init()
+}
+
+// updateOpening quits once the projects were opened without any hook failure.
+// It must be called after the runner pane handled the message.
+func (m *Model) updateOpening() tea.Cmd {
+	if m.opening == nil || m.runner.Running() || m.runner.Err() != nil || m.opening.warned {
+		return nil
+	}
+	return m.finishOpening()
+}
+
+// finishOpening quits after the projects were opened with hooks, or returns to the list if none was opened.
+// It must only be called once the hooks and launchers stopped.
+func (m *Model) finishOpening() tea.Cmd {
+	o := m.opening
+	m.opening = nil
+	m.runner = nil
+
+	if len(o.opened) == 0 {
+		return nil
+	}
+	return m.quitAfterOpening(o.opened, o.foreground)
+}
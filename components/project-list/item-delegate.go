@@ -3,6 +3,10 @@ package projectlist
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
+
+	"ls-projects/models/project"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,7 +14,14 @@ import (
 )
 
 type itemDelegate struct {
-	movingModeInitialIndex int
+	movedProjectID string
+	selected       map[string]bool
+
+	// whether projects are listed under their group's header
+	grouped bool
+
+	// names of the projects by ID, used to list the members of sets
+	names map[string]string
 }
 
 var (
@@ -24,13 +35,98 @@ var (
 	selectedItemForMovingStyle = lipgloss.NewStyle().
 					Foreground(lipgloss.Color("#4d4d4d")).
 					PaddingLeft(2)
+
+	selectionMarkerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#8E6CBF"))
+
+	setMarkerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDB771"))
+
+	setMembersStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	remoteMarkerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#25A065"))
+
+	tagsStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#6C91BF")).
+			Faint(true)
+
+	descriptionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
+
+	pinnedMarkerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#DDB771"))
+
+	lastOpenedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Italic(true)
+
+	groupStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#25A065")).
+			Bold(true)
+
+	groupCountStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	missingItemStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
+
+	missingMarkerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#E84855"))
 )
 
+// maxDescriptionWidth is the number of characters of a description shown beside the project's name.
+const maxDescriptionWidth = 50
+
 func (d itemDelegate) Height() int                               { return 1 }
 func (d itemDelegate) Spacing() int                              { return 0 }
 func (d itemDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
 func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	str := fmt.Sprintf("%d. %s", index+1, listItem.FilterValue())
+	switch item := listItem.(type) {
+	case project.Project:
+		name := item.Name
+		if item.Missing {
+			name = missingMarkerStyle.Render("⚠") + " " + missingItemStyle.Render(name)
+		}
+		if item.Pinned {
+			name = pinnedMarkerStyle.Render("★") + " " + name
+		}
+		str = fmt.Sprintf("%d. %s", index+1, name)
+		if d.selected[item.ID] {
+			str = fmt.Sprintf("%d. %s %s", index+1, selectionMarkerStyle.Render("✓"), name)
+		}
+		if d.grouped && item.Group != "" && !item.Pinned {
+			str = groupIndent(project.Group{Name: item.Group}.Depth()) + str
+		}
+		if item.IsRemote() {
+			str += " " + remoteMarkerStyle.Render("⇄ "+item.Host)
+		}
+		if len(item.Tags) > 0 {
+			str += " " + tagsStyle.Render("#"+strings.Join(item.Tags, " #"))
+		}
+		if item.Description != "" {
+			str += " " + descriptionStyle.Render("— "+shortDescription(item.Description))
+		}
+		if item.LastOpened != nil {
+			str += " " + lastOpenedStyle.Render("opened "+relativeTime(*item.LastOpened, time.Now()))
+		}
+	case groupHeader:
+		marker := "▾"
+		if item.Collapsed {
+			marker = "▸"
+		}
+		str = fmt.Sprintf("%s%s %s %s", groupIndent(item.Depth()-1), marker, groupStyle.Render(item.BaseName()),
+			groupCountStyle.Render(fmt.Sprintf("(%d)", item.count)))
+	case project.Set:
+		members := make([]string, len(item.Members))
+		for i, id := range item.Members {
+			members[i] = d.names[id]
+		}
+		str = fmt.Sprintf("%d. %s %s %s", index+1, setMarkerStyle.Render("◆"), item.Name,
+			setMembersStyle.Render("("+strings.Join(members, ", ")+")"))
+	}
 
 	fn := itemStyle.Render
 	if index == m.Index() {
@@ -38,7 +134,7 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 			strs = append([]string{">"}, strs...)
 			return selectedItemStyle.Render(strs...)
 		}
-	} else if index == d.movingModeInitialIndex {
+	} else if p, ok := listItem.(project.Project); ok && d.movedProjectID != "" && p.ID == d.movedProjectID {
 		fn = func(strs ...string) string {
 			strs = append([]string{"*"}, strs...)
 			return selectedItemForMovingStyle.Render(strs...)
@@ -47,3 +143,35 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 
 	fmt.Fprint(w, fn(str))
 }
+
+// shortDescription returns the description on a single line, truncated to maxDescriptionWidth characters.
+func shortDescription(description string) string {
+	d := []rune(strings.Join(strings.Fields(description), " "))
+	if len(d) > maxDescriptionWidth {
+		return string(d[:maxDescriptionWidth-1]) + "…"
+	}
+	return string(d)
+}
+
+// groupIndent returns the indentation of an item nested in the given number of groups.
+func groupIndent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
+
+// relativeTime returns how long before now the time is, e.g. "2h ago".
+func relativeTime(t time.Time, now time.Time) string {
+	switch d := now.Sub(t); {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30))
+	default:
+		return fmt.Sprintf("%dy ago", int(d.Hours()/24/365))
+	}
+}
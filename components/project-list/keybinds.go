@@ -2,15 +2,17 @@ package projectlist
 
 import (
 	"fmt"
+	"ls-projects/components/picker"
 	projectform "ls-projects/components/project-form"
 	searchinput "ls-projects/components/search-input"
+	"ls-projects/models/config"
+	"ls-projects/models/launcher"
 	"ls-projects/models/project"
-	"os/exec"
+	"strings"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/marcantoineg/fileutil"
 )
 
 var keybinds = _keybinds{}
@@ -20,22 +22,41 @@ type _keybinds struct{}
 func (_keybinds) defineShort() []key.Binding {
 	return []key.Binding{
 		key.NewBinding(key.WithKeys("enter", "space"), key.WithHelp("⏎/space", "open a project")),
+		key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "toggle selection")),
 	}
 }
 
 func (_keybinds) defineLong() []key.Binding {
 	return []key.Binding{
 		key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add a project")),
+		key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open selected project with...")),
+		key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "run a command or task of selected project")),
 		key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit selected project")),
-		key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete selected project")),
+		key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete selected project(s)")),
 		key.NewBinding(key.WithKeys("f", "/"), key.WithHelp("f|/", "find in projects")),
-		key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "yank selected project's path to clipboard")),
+		key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "filter projects by tags")),
+		key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "yank selected project(s) path to clipboard")),
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("⏎", "collapse/expand selected group")),
 		key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "enter moving mode")),
+		key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "toggle sorting by frecency")),
+		key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "save selected projects as a set")),
+		key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pin/unpin selected project")),
+		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "relocate selected missing project")),
+		key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "remove all missing projects")),
 	}
 }
 
 // handleKeyMsg handles the keybinding part of the Update function.
 func (_keybinds) handle(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// the last launch error stays visible while navigating the list only
+	if m.launchError != nil {
+		switch msg.String() {
+		case "up", "down", "k", "j":
+		default:
+			resetListTitle(m)
+		}
+	}
+
 	switch keypress := msg.String(); keypress {
 	case "ctrl+c", "q", "esc":
 		if m.movingModeActive {
@@ -43,37 +64,71 @@ func (_keybinds) handle(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if keypress == "esc" && len(m.selected) > 0 {
+			m.clearSelection()
+			return m, nil
+		}
+
 		m.quitting = true
-		return m, tea.Quit
+		return m, quit
 
 	case "enter", "space":
 		if len(m.list.Items()) == 0 {
 			return m, nil
 		}
 		if !m.movingModeActive {
-			selectedItem := m.list.SelectedItem().(project.Project)
-			m.choice = &selectedItem
+			if s, ok := m.list.SelectedItem().(project.Set); ok {
+				if m.printPath {
+					return m, nil
+				}
+				return m, m.openSet(s)
+			}
 
-			cmd := exec.Command("code", "-n", ".")
-			cmd.Dir = fileutil.ReplaceTilde(m.choice.Path)
+			if h, ok := m.list.SelectedItem().(groupHeader); ok {
+				return m, m.toggleGroup(h)
+			}
 
-			err := cmd.Run()
-			if err != nil {
-				return m, func() tea.Msg { return fatalErrorMsg{err} }
+			selectedItem := m.list.SelectedItem().(project.Project)
+			if m.printPath {
+				if selectedItem.Missing {
+					return m, func() tea.Msg { return launchErrorMsg{name: selectedItem.Name, err: errMissingProject} }
+				}
+				m.choices = []project.Project{selectedItem}
+				m.recordOpen(m.choices)
+				return m, quit
+			}
+
+			projects := []project.Project{selectedItem}
+			if len(m.selected) > 0 {
+				projects = m.selectedProjects()
 			}
 
-			return m, tea.Quit
+			return m, m.openProjects(projects, resolveLaunchers(projects))
 		} else {
-			projects, err := project.SwapIndex(m.movingModeInitialIndex, m.list.Index())
+			if h, ok := m.list.SelectedItem().(groupHeader); ok {
+				return m, m.moveToGroup(h)
+			}
+
+			target, ok := m.list.SelectedItem().(project.Project)
+			if !ok {
+				return m, nil
+			}
+			movedID := m.movedProjectID
+			projects, err := project.SwapByID(movedID, target.ID)
 			if err != nil {
-				m.Update(projectform.ProjectUpdateErrorMsg(err))
+				m.list.Styles.Title = Style.ErrorTitleStyle
+				m.list.Title = fmt.Sprintf("error moving project '%s': %s", target.Name, err)
 				return m, nil
 			}
 
-			m.items = castToListItem(projects)
-			m.list.SetItems(m.items)
+			cmd := m.setProjects(projects)
 
 			disableMovingMode(m)
+
+			if p, ok := m.projectByID(movedID); ok {
+				return m, tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectReordered, Project: p}))
+			}
+			return m, cmd
 		}
 
 	case "a":
@@ -86,34 +141,131 @@ func (_keybinds) handle(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "e":
 		if !m.movingModeActive {
-			if p, ok := m.items[m.list.Index()].(project.Project); ok {
+			if s, ok := m.list.SelectedItem().(project.Set); ok {
+				return m, m.promptSetName(&s)
+			}
+
+			if p, ok := m.list.SelectedItem().(project.Project); ok {
 				f := projectform.NewProjectForm(m, &p)
 				m.projectForm = &f
-			}
 
-			return m.projectForm.Update(nil)
+				return m.projectForm.Update(nil)
+			}
 		}
 
 	case "d":
+		if !m.movingModeActive && len(m.selected) > 0 {
+			m.askConfirmation(fmt.Sprintf("delete %d selected project(s)?", len(m.selectedProjects())), (*Model).deleteSelection)
+			return m, nil
+		}
+
 		if !m.movingModeActive {
+			if s, ok := m.list.SelectedItem().(project.Set); ok {
+				sets, err := project.DeleteSet(s.Name)
+				if err != nil {
+					m.list.Styles.Title = Style.ErrorTitleStyle
+					m.list.Title = fmt.Sprintf("error deleting set '%s'", s.Name)
+					return m, nil
+				}
+
+				cmd := m.setSets(sets)
+
+				m.list.Styles.Title = Style.SuccessTitleStyle
+				m.list.Title = fmt.Sprintf("set '%s' deleted", s.Name)
+
+				return m, cmd
+			}
+
 			if p, ok := m.list.SelectedItem().(project.Project); ok {
-				projects, err := project.Delete(m.list.Index(), p)
+				projects, err := project.DeleteByID(p.ID)
 				if err != nil {
 					m.list.Styles.Title = Style.ErrorTitleStyle
 					m.list.Title = fmt.Sprintf("error deleting project '%s'", p.Name)
+					return m, nil
 				}
 
-				m.items = castToListItem(projects)
-				cmd := m.list.SetItems(m.items)
+				cmd := m.setProjects(projects)
 
 				m.list.Styles.Title = Style.SuccessTitleStyle
 				m.list.Title = fmt.Sprintf("project '%s' deleted", p.Name)
 
-				return m, cmd
+				return m, tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectDeleted, Project: p}))
+			}
+		}
+
+	case "o":
+		if !m.movingModeActive {
+			if p, ok := m.list.SelectedItem().(project.Project); ok {
+				m.actions = launcher.Actions(config.GetInstance(), p)
+
+				items := make([]picker.Item, len(m.actions))
+				for i, a := range m.actions {
+					items[i] = picker.Item{Title: a.Name, Description: a.String()}
+				}
+
+				menu := picker.NewPicker(fmt.Sprintf("Open '%s' with...", p.Name), items)
+				m.actionMenu = &menu
+
+				return m, nil
+			}
+		}
+
+	case "c":
+		if !m.movingModeActive {
+			if p, ok := m.list.SelectedItem().(project.Project); ok {
+				return m, detectTasks(p)
+			}
+		}
+
+	case "x":
+		if !m.movingModeActive {
+			if p, ok := m.list.SelectedItem().(project.Project); ok {
+				m.toggleSelection(p)
+				m.list.CursorDown()
+				return m, nil
+			}
+		}
+
+	case "p":
+		if !m.movingModeActive {
+			if p, ok := m.list.SelectedItem().(project.Project); ok {
+				return m, m.togglePin(p)
+			}
+		}
+
+	case "r":
+		if !m.movingModeActive {
+			if p, ok := m.list.SelectedItem().(project.Project); ok && p.Missing {
+				return m, m.promptRelocation(p)
 			}
 		}
 
+	case "D":
+		if n := len(m.missingProjects()); !m.movingModeActive && n > 0 {
+			m.askConfirmation(fmt.Sprintf("remove %d missing project(s)?", n), (*Model).removeMissing)
+			return m, nil
+		}
+
+	case "S":
+		if !m.movingModeActive && len(m.selected) > 0 {
+			return m, m.promptSetName(nil)
+		}
+
 	case "y":
+		if !clipboard.Unsupported && !m.movingModeActive && len(m.selected) > 0 {
+			projects := m.selectedProjects()
+			paths := make([]string, len(projects))
+			for i, p := range projects {
+				paths[i] = p.Path
+			}
+			clipboard.WriteAll(strings.Join(paths, "\n"))
+
+			m.list.Styles.Title = Style.SuccessTitleStyle
+			m.list.Title = fmt.Sprintf("paths of %d project(s) copied", len(projects))
+
+			return m, nil
+		}
+
 		if !clipboard.Unsupported && !m.movingModeActive {
 			if p, ok := m.list.SelectedItem().(project.Project); ok {
 				clipboard.WriteAll(p.Path)
@@ -125,22 +277,33 @@ func (_keybinds) handle(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case "s":
+		if !m.movingModeActive {
+			return m, m.toggleSortMode()
+		}
+
 	case "m":
-		m.movingModeInitialIndex = m.list.Index()
-		m.list.SetDelegate(itemDelegate{movingModeInitialIndex: m.movingModeInitialIndex})
+		p, ok := m.list.SelectedItem().(project.Project)
+		if !ok {
+			return m, nil
+		}
+
+		if m.sortMode == config.FrecencySort {
+			m.list.Styles.Title = Style.ErrorTitleStyle
+			m.list.Title = "projects can only be moved when sorted manually"
+			return m, nil
+		}
+
+		m.movedProjectID = p.ID
 		m.movingModeActive = true
+		m.updateDelegate()
 
 		m.list.Styles.Title = Style.MovingModeTitleStyle
-		m.list.Title = "select another project to swap position"
+		m.list.Title = "select another project to swap position or a group to move into"
 
-	case "f", "/":
+	case "f", "/", "#":
 		if m.searchInput == nil {
-			projectNames := make([]string, len(m.items))
-			for i, p := range m.items {
-				projectNames[i] = p.(project.Project).Name
-			}
-
-			s := searchinput.NewSearchInput(projectNames)
+			s := searchinput.NewSearchInput(m.searchItems())
 			m.searchInput = &s
 			m.searchInput.Focus()
 			m.typingSearchTerm = true
@@ -150,6 +313,15 @@ func (_keybinds) handle(m *Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchInput.Focus()
 		}
 
+		// the tag is appended to the search term already typed
+		if keypress == "#" {
+			term := m.searchInput.Value()
+			if term != "" && !strings.HasSuffix(term, " ") {
+				term += " "
+			}
+			m.searchInput.SetValue(term + "#")
+		}
+
 	case "up":
 		if m.searchInput != nil && m.list.Paginator.Page == 0 && m.list.Cursor() == 0 {
 			m.typingSearchTerm = true
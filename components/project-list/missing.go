@@ -0,0 +1,129 @@
+package projectlist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ls-projects/models/launcher"
+	"ls-projects/models/project"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var errMissingProject = errors.New("the project's path or one of its folders doesn't exist, press r to relocate it")
+
+// missingProjects returns the projects whose path or an additional folder is missing, in file order.
+func (m Model) missingProjects() []project.Project {
+	var missing []project.Project
+	for _, p := range m.projects() {
+		if p.Missing {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
+
+// showMissingWarning warns in the list's title about the projects that were not found on the host, if any.
+func (m *Model) showMissingWarning() {
+	missing := m.missingProjects()
+	if len(missing) == 0 {
+		return
+	}
+
+	m.list.Styles.Title = Style.ErrorTitleStyle
+	m.list.Title = fmt.Sprintf("%d project(s) not found, press r to relocate or D to remove", len(missing))
+}
+
+// promptRelocation shows the input changing the path of the missing project.
+func (m *Model) promptRelocation(p project.Project) tea.Cmd {
+	t := textinput.New()
+	t.Placeholder = "New path..."
+	t.Cursor.Style = Style.SetNameCursorStyle
+	t.SetValue(p.Path)
+
+	m.pathInput = &t
+	m.relocated = &p
+
+	m.list.Styles.Title = Style.MovingModeTitleStyle
+	m.list.Title = fmt.Sprintf("relocate '%s'", p.Name)
+
+	return m.pathInput.Focus()
+}
+
+// handlePathInput handles the key messages while the path input is shown.
+func (m *Model) handlePathInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc":
+		m.pathInput = nil
+		m.relocated = nil
+		resetListTitle(m)
+		return m, nil
+
+	case "enter":
+		return m, m.submitRelocation()
+	}
+
+	var cmd tea.Cmd
+	*m.pathInput, cmd = m.pathInput.Update(msg)
+	return m, cmd
+}
+
+// submitRelocation saves the relocated project with its new path.
+// On error or if the path still doesn't exist, the input stays open so the path can be fixed.
+func (m *Model) submitRelocation() tea.Cmd {
+	p := *m.relocated
+	p.Path = strings.TrimSpace(m.pathInput.Value())
+
+	if !p.ValidatePath() {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("'%s' or one of its folders doesn't exist", p.Path)
+		return nil
+	}
+
+	projects, err := project.UpdateByID(p.ID, p)
+	if err != nil {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = err.Error()
+		return nil
+	}
+
+	m.pathInput = nil
+	m.relocated = nil
+	cmd := m.setProjects(projects)
+
+	m.list.Styles.Title = Style.SuccessTitleStyle
+	m.list.Title = fmt.Sprintf("project '%s' relocated!", p.Name)
+
+	return tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectUpdated, Project: p}))
+}
+
+// removeMissing deletes every missing project from the disk.
+func (m *Model) removeMissing() tea.Cmd {
+	missing := m.missingProjects()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	for i, p := range missing {
+		projects, err := project.DeleteByID(p.ID)
+		if err != nil {
+			cmd := m.setProjects(m.onDiskProjects)
+
+			m.list.Styles.Title = Style.ErrorTitleStyle
+			m.list.Title = fmt.Sprintf("error deleting project '%s'", p.Name)
+			// the projects deleted before the failure are still notified
+			return tea.Batch(cmd, notifyChange(changeEvents(launcher.ProjectDeleted, missing[:i])...))
+		}
+		m.onDiskProjects = projects
+		delete(m.selected, p.ID)
+	}
+
+	cmd := m.setProjects(m.onDiskProjects)
+
+	m.list.Styles.Title = Style.SuccessTitleStyle
+	m.list.Title = fmt.Sprintf("%d missing project(s) removed", len(missing))
+
+	return tea.Batch(cmd, notifyChange(changeEvents(launcher.ProjectDeleted, missing)...))
+}
@@ -1,8 +1,37 @@
 package projectlist
 
-import "github.com/charmbracelet/bubbles/list"
+import (
+	"os/exec"
+
+	"ls-projects/models/project"
+	"ls-projects/models/tasks"
+)
 
 type fatalErrorMsg struct {
 	err error
 }
-type initMsg struct{ items []list.Item }
+type launchErrorMsg struct {
+	name string
+	err  error
+	// projects opened before the launch failed
+	opened []project.Project
+}
+type launchedMsg struct {
+	projects []project.Project
+	// command to run in the foreground, nil if no launcher needs the terminal
+	foreground *exec.Cmd
+}
+type openingStoppedMsg struct{}
+type changeHookErrorMsg struct {
+	err error
+}
+type tasksDetectedMsg struct {
+	projectID string
+	targets   []tasks.Target
+	err       error
+}
+type initMsg struct {
+	projects []project.Project
+	sets     []project.Set
+	groups   []project.Group
+}
@@ -4,30 +4,58 @@ import (
 	"fmt"
 	"strings"
 
+	commandrunner "ls-projects/components/command-runner"
+	"ls-projects/components/picker"
 	projectform "ls-projects/components/project-form"
 	searchinput "ls-projects/components/search-input"
+	"ls-projects/models/config"
+	"ls-projects/models/launcher"
 	"ls-projects/models/project"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 type Model struct {
-	list                   list.Model
-	items                  []list.Item
-	choice                 *project.Project
-	projectForm            *projectform.Model
-	fatalError             error
-	movingModeActive       bool
-	movingModeInitialIndex int
-	quitting               bool
-	searchInput            *searchinput.Model
-	typingSearchTerm       bool
+	list             list.Model
+	items            []list.Item
+	onDiskProjects   []project.Project
+	choices          []project.Project
+	projectForm      *projectform.Model
+	fatalError       error
+	movingModeActive bool
+	movedProjectID   string
+	quitting         bool
+	searchInput      *searchinput.Model
+	typingSearchTerm bool
+	actionMenu       *picker.Model
+	actions          []config.Action
+	printPath        bool
+	launchError      error
+	selected         map[string]bool
+	sets             []project.Set
+	groups           []project.Group
+	sortMode         string
+	setNameInput     *textinput.Model
+	renamedSet       *project.Set
+	pathInput        *textinput.Model
+	relocated        *project.Project
+	confirmed        func(m *Model) tea.Cmd
+	commands         []project.Command
+	runner           *commandrunner.Model
+	opening          *opening
+	launching        bool
 }
 
-func NewProjectList() tea.Model {
-	l := list.New([]list.Item{}, itemDelegate{movingModeInitialIndex: -1}, listWidth, listHeight)
+// NewProjectList returns the project list model.
+// When printPath is true, selecting a project quits without launching it so its path can be printed by the caller.
+func NewProjectList(printPath bool) tea.Model {
+	selected := map[string]bool{}
+	sortMode := config.GetInstance().SortMode
+	delegate := itemDelegate{selected: selected, grouped: sortMode != config.FrecencySort}
+	l := list.New([]list.Item{}, delegate, listWidth, listHeight)
 
 	l.Title = listInitialTitle
 	l.SetShowStatusBar(false)
@@ -43,17 +71,36 @@ func NewProjectList() tea.Model {
 	l.AdditionalShortHelpKeys = keybinds.defineShort
 	l.AdditionalFullHelpKeys = keybinds.defineLong
 
-	m := Model{list: l}
+	m := Model{list: l, printPath: printPath, selected: selected, sortMode: sortMode}
 	return m
 }
 
+// SelectedPath returns the directory of the selected project, or an empty string if none was selected.
+// For a workspace file, it's the file's directory.
+func (m Model) SelectedPath() string {
+	if len(m.choices) == 0 {
+		return ""
+	}
+	return launcher.ProjectDir(m.choices[0])
+}
+
 func (m Model) Init() tea.Cmd {
 	projects, err := project.GetAll()
 	if err != nil {
 		return func() tea.Msg { return fatalErrorMsg{err} }
 	}
 
-	return func() tea.Msg { return initMsg{castToListItem(projects)} }
+	sets, err := project.GetSets()
+	if err != nil {
+		return func() tea.Msg { return fatalErrorMsg{err} }
+	}
+
+	groups, err := project.GetGroups()
+	if err != nil {
+		return func() tea.Msg { return fatalErrorMsg{err} }
+	}
+
+	return func() tea.Msg { return initMsg{projects, sets, groups} }
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -65,46 +112,100 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.projectForm = nil
 		m.quitting = true
 
-		return m, tea.Quit
+		return m, quit
 
 	case tea.WindowSizeMsg:
 		m.list.SetWidth(msg.Width)
+		if m.runner != nil {
+			return m.updateRunner(msg)
+		}
+		return m, nil
+
+	case commandrunner.OutputMsg:
+		if m.runner != nil {
+			return m.updateRunner(msg)
+		}
 		return m, nil
 
+	case commandrunner.ExitMsg:
+		if m.runner != nil {
+			model, cmd := m.updateRunner(msg)
+			if openingCmd := m.updateOpening(); openingCmd != nil {
+				return m, openingCmd
+			}
+			return model, cmd
+		}
+		return m, nil
+
+	case tasksDetectedMsg:
+		// the cursor may have moved to another project while detecting the tasks
+		p, ok := m.list.SelectedItem().(project.Project)
+		if !ok || p.ID != msg.projectID {
+			return m, nil
+		}
+
+		return m, m.openCommandMenu(p, msg.targets, msg.err)
+
+	case commandrunner.CloseRunner:
+		m.runner = nil
+		// projects opened before a hook failed or the pane was closed stay open, the app quits as if no hook failed
+		if m.opening != nil {
+			return m, m.opening.wait()
+		}
+		return m, nil
+
+	case openingStoppedMsg:
+		return m, m.finishOpening()
+
 	case initMsg:
-		m.items = msg.items
-		m.list.SetItems(m.items)
+		m.onDiskProjects = msg.projects
+		m.sets = msg.sets
+		m.groups = msg.groups
+		m.refreshItems()
+		m.showMissingWarning()
 
 	case projectform.ProjectCreatedMsg:
-		projects, err := project.Save(m.list.Index(), msg.Project)
+		projects, err := project.SaveAfter(m.insertionID(), msg.Project)
 		if err != nil {
-			m.Update(projectform.ProjectCreationErrorMsg(err))
+			m.projectForm = nil
+			m.list.Styles.Title = Style.ErrorTitleStyle
+			m.list.Title = fmt.Sprintf("error adding project '%s': %s", msg.Project.Name, err)
 			return m, nil
 		}
 
-		m.items = castToListItem(projects)
-		m.list.SetItems(m.items)
+		cmd := m.setProjects(projects)
 
 		m.list.Styles.Title = Style.SuccessTitleStyle
 		m.list.Title = fmt.Sprintf("project '%s' added!", msg.Project.Name)
 
 		m.projectForm = nil
 
+		if p, ok := projectNamed(projects, msg.Project.Name); ok {
+			return m, tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectAdded, Project: p}))
+		}
+		return m, cmd
+
 	case projectform.ProjectUpdatedMsg:
-		projects, err := project.Update(m.list.Index(), msg.Project)
+		projects, err := project.UpdateByID(msg.Project.ID, msg.Project)
 		if err != nil {
-			m.Update(projectform.ProjectUpdateErrorMsg(err))
+			m.projectForm = nil
+			m.list.Styles.Title = Style.ErrorTitleStyle
+			m.list.Title = fmt.Sprintf("error updating project '%s': %s", msg.Project.Name, err)
 			return m, nil
 		}
 
-		m.items = castToListItem(projects)
-		m.list.SetItems(m.items)
+		cmd := m.setProjects(projects)
 
 		m.list.Styles.Title = Style.SuccessTitleStyle
 		m.list.Title = fmt.Sprintf("project '%s' updated!", msg.Project.Name)
 
 		m.projectForm = nil
 
+		if p, ok := m.projectByID(msg.Project.ID); ok {
+			return m, tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectUpdated, Project: p}))
+		}
+		return m, cmd
+
 	case projectform.NoProjectCreatedMsg:
 		resetListTitle(&m)
 		m.projectForm = nil
@@ -121,9 +222,82 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.typingSearchTerm = false
 		m.filterList(msg.FilteredItemsIndices)
 
+	case changeHookErrorMsg:
+		// the change is saved even though a hook failed, only the failure is reported
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("change hook failed: %s", firstLine(msg.err.Error()))
+
+		return m, nil
+
+	case launchedMsg:
+		m.launching = false
+		return m, m.quitAfterOpening(msg.projects, msg.foreground)
+
+	case launchErrorMsg:
+		m.launching = false
+		m.choices = nil
+		m.launchError = msg.err
+
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("error opening '%s'", msg.name)
+
+		if len(msg.opened) > 0 {
+			m.recordOpen(msg.opened)
+			// the items are rebuilt so the next changes don't revert the recorded usage
+			cmd = m.refreshItems()
+
+			names := make([]string, len(msg.opened))
+			for i, p := range msg.opened {
+				names[i] = p.Name
+			}
+			m.list.Title += fmt.Sprintf(", already opened: %s", strings.Join(names, ", "))
+		}
+
+		return m, cmd
+
+	case picker.CancelPick:
+		m.actionMenu = nil
+		m.actions = nil
+		m.commands = nil
+
+	case picker.SubmitPick:
+		p, ok := m.list.SelectedItem().(project.Project)
+		if ok && m.commands != nil && msg.Index >= 0 && msg.Index < len(m.commands) {
+			command := m.commands[msg.Index]
+			m.actionMenu = nil
+			m.commands = nil
+
+			return m, m.runCommand(p, command)
+		}
+
+		if !ok || msg.Index < 0 || msg.Index >= len(m.actions) {
+			return m, nil
+		}
+
+		action := m.actions[msg.Index]
+		m.actionMenu = nil
+		m.actions = nil
+
+		return m, m.openProject(p, action.Launcher)
+
 	// Keybinding
 	case tea.KeyMsg:
-		if m.typingSearchTerm && m.searchInput != nil {
+		if m.launching {
+			return m, nil
+		} else if m.runner != nil {
+			return m.updateRunner(msg)
+		} else if m.confirmed != nil {
+			return m.handleConfirmation(msg)
+		} else if m.setNameInput != nil {
+			return m.handleSetNameInput(msg)
+		} else if m.pathInput != nil {
+			return m.handlePathInput(msg)
+		} else if m.actionMenu != nil {
+			model, cmd := m.actionMenu.Update(msg)
+			menuModel := model.(picker.Model)
+			m.actionMenu = &menuModel
+			return m, cmd
+		} else if m.typingSearchTerm && m.searchInput != nil {
 			model, cmd := m.searchInput.Update(msg)
 			searchModel := model.(searchinput.Model)
 			m.searchInput = &searchModel
@@ -144,9 +318,19 @@ func (m Model) View() string {
 		)
 	}
 
-	if m.choice != nil {
+	if len(m.choices) > 0 && m.printPath {
+		return Style.QuitTextStyle.Render(
+			fmt.Sprintf("Moving to %s 📂", Style.PathTextStyle.Render(m.choices[0].Path)),
+		)
+	}
+
+	if len(m.choices) > 0 {
+		paths := make([]string, len(m.choices))
+		for i, p := range m.choices {
+			paths[i] = Style.PathTextStyle.Render(p.Path)
+		}
 		return Style.QuitTextStyle.Render(
-			fmt.Sprintf("Opening %s 💻", Style.PathTextStyle.Render(m.choice.Path)),
+			fmt.Sprintf("Opening %s 💻", strings.Join(paths, ", ")),
 		)
 	}
 
@@ -161,13 +345,33 @@ func (m Model) View() string {
 		return m.projectForm.View()
 	}
 
+	if m.runner != nil {
+		return m.runner.View()
+	}
+
+	if m.actionMenu != nil {
+		return m.actionMenu.View()
+	}
+
 	var sb strings.Builder
 
 	if m.searchInput != nil {
 		sb.WriteString(m.searchInput.View() + "\n")
 	}
 
+	if m.setNameInput != nil {
+		sb.WriteString(Style.SetNameInputStyle.Render(m.setNameInput.View()) + "\n")
+	}
+
+	if m.pathInput != nil {
+		sb.WriteString(Style.SetNameInputStyle.Render(m.pathInput.View()) + "\n")
+	}
+
 	sb.WriteString("\n" + m.list.View())
 
+	if m.launchError != nil {
+		sb.WriteString("\n" + Style.ErrorDetailsStyle.Render(launchErrorDetails(m.launchError)))
+	}
+
 	return sb.String()
 }
@@ -0,0 +1,1067 @@
+package projectlist
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	projectform "ls-projects/components/project-form"
+	"ls-projects/models/config"
+	"ls-projects/models/launcher"
+	"ls-projects/models/project"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	// setup
+	os.Remove(config.GetInstance().ProjectsPath)
+
+	code := m.Run()
+
+	// teardown
+	os.Remove(config.GetInstance().ProjectsPath)
+	os.Remove(config.GetInstance().ConfigPath)
+
+	os.Exit(code)
+}
+
+const initialDiskData = `
+[
+	{
+		"id": "1",
+		"name": "api",
+		"path": "./"
+	},
+	{
+		"id": "2",
+		"name": "web",
+		"path": "./"
+	},
+	{
+		"id": "3",
+		"name": "docs",
+		"path": "./"
+	}
+]
+`
+
+func Test_FilteredActions(t *testing.T) {
+	testRuns := []struct {
+		testName   string
+		searchTerm string
+		act        func(m tea.Model) tea.Model
+
+		expectedProjects []project.Project
+	}{
+		{
+			testName:   "delete filtered project",
+			searchTerm: "docs",
+			act: func(m tea.Model) tea.Model {
+				return press(m, "d")
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "./"},
+			},
+		},
+		{
+			testName:   "delete selection made in filtered list",
+			searchTerm: "web",
+			act: func(m tea.Model) tea.Model {
+				return press(press(press(m, "x"), "d"), "y")
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "3", Name: "docs", Path: "./"},
+			},
+		},
+		{
+			testName:   "edit filtered project",
+			searchTerm: "docs",
+			act: func(m tea.Model) tea.Model {
+				p := selectedProject(m)
+				p.Name = "handbook"
+				m, _ = m.Update(projectform.ProjectUpdatedMsg{Project: p})
+				return m
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "./"},
+				{ID: "3", Name: "handbook", Path: "./"},
+			},
+		},
+		{
+			testName:   "swap filtered projects",
+			searchTerm: "docs",
+			act: func(m tea.Model) tea.Model {
+				m = press(m, "m")
+				m = search(m, "api")
+				return pressKey(m, tea.KeyEnter)
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "3", Name: "docs", Path: "./"},
+				{ID: "2", Name: "web", Path: "./"},
+				{ID: "1", Name: "api", Path: "./"},
+			},
+		},
+		{
+			testName:   "pin filtered project",
+			searchTerm: "web",
+			act: func(m tea.Model) tea.Model {
+				return press(m, "p")
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "./", Pinned: true},
+				{ID: "3", Name: "docs", Path: "./"},
+			},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(initialDiskData)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+			m = search(m, testRun.searchTerm)
+			assert.Equal(t, testRun.searchTerm, selectedProject(m).Name)
+
+			testRun.act(m)
+
+			projects, err := project.GetAll()
+			assert.Nil(t, err)
+			assert.Equal(t, testRun.expectedProjects, projects)
+		})
+	}
+}
+
+func Test_FilterKeptAfterMutation(t *testing.T) {
+	saveStringToFile(initialDiskData)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = search(m, "docs")
+	m = press(m, "p")
+
+	items := listModel(m).list.Items()
+	assert.Len(t, items, 1)
+	assert.Equal(t, "docs", selectedProject(m).Name)
+	assert.True(t, selectedProject(m).Pinned)
+}
+
+func Test_ChangeHooks(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		act      func(m tea.Model) tea.Model
+
+		expectedEvents []string
+	}{
+		{
+			testName: "add project",
+			act: func(m tea.Model) tea.Model {
+				m, _ = m.Update(projectform.ProjectCreatedMsg{Project: project.Project{ID: "4", Name: "blog", Path: "./tests"}})
+				return m
+			},
+
+			expectedEvents: []string{`{"event":"add","project":{"id":"4","name":"blog","path":"./tests"}}`},
+		},
+		{
+			testName: "edit project",
+			act: func(m tea.Model) tea.Model {
+				p := selectedProject(m)
+				p.Name = "handbook"
+				m, _ = m.Update(projectform.ProjectUpdatedMsg{Project: p})
+				return m
+			},
+
+			expectedEvents: []string{`{"event":"update","project":{"id":"1","name":"handbook","path":"./"}}`},
+		},
+		{
+			testName: "delete selection",
+			act: func(m tea.Model) tea.Model {
+				m = press(m, "x")
+				m = press(m, "x")
+				return press(press(m, "d"), "y")
+			},
+
+			expectedEvents: []string{
+				`{"event":"delete","project":{"id":"1","name":"api","path":"./"}}`,
+				`{"event":"delete","project":{"id":"2","name":"web","path":"./"}}`,
+			},
+		},
+		{
+			testName: "delete selection failing partway",
+			act: func(m tea.Model) tea.Model {
+				m = press(m, "x")
+				m = press(m, "x")
+				// web is deleted by another instance, its deletion failing after api's one
+				saveStringToFile(`[{"id": "1", "name": "api", "path": "./"}, {"id": "3", "name": "docs", "path": "./"}]`)
+				return press(press(m, "d"), "y")
+			},
+
+			expectedEvents: []string{`{"event":"delete","project":{"id":"1","name":"api","path":"./"}}`},
+		},
+		{
+			testName: "remove missing projects failing partway",
+			act: func(m tea.Model) tea.Model {
+				saveStringToFile(missingDiskData)
+				m, _ = m.Update(m.Init()())
+				// docs is deleted by another instance, its deletion failing after web's one
+				saveStringToFile(`[{"id": "1", "name": "api", "path": "./"}, {"id": "2", "name": "web", "path": "not-a-valid-path"}]`)
+				return press(press(m, "D"), "y")
+			},
+
+			expectedEvents: []string{`{"event":"delete","project":{"id":"2","name":"web","path":"not-a-valid-path"}}`},
+		},
+		{
+			testName: "reorder project",
+			act: func(m tea.Model) tea.Model {
+				m = press(m, "m")
+				m = pressKey(m, tea.KeyDown)
+				return pressKey(m, tea.KeyEnter)
+			},
+
+			expectedEvents: []string{`{"event":"reorder","project":{"id":"1","name":"api","path":"./"}}`},
+		},
+		{
+			testName: "consecutive changes keep their order",
+			act: func(m tea.Model) tea.Model {
+				m, _ = m.Update(projectform.ProjectCreatedMsg{Project: project.Project{ID: "4", Name: "blog", Path: "./tests"}})
+				return press(m, "d")
+			},
+
+			expectedEvents: []string{
+				`{"event":"add","project":{"id":"4","name":"blog","path":"./tests"}}`,
+				`{"event":"delete","project":{"id":"1","name":"api","path":"./"}}`,
+			},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "events")
+			t.Setenv("OUT", out)
+			// the hook is slow so that quitting happens before the events were sent
+			setChangeHooks(t, `sleep 0.05; cat >> "$OUT"; echo >> "$OUT"`)
+			saveStringToFile(initialDiskData)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+			m = testRun.act(m)
+
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+			assert.IsType(t, tea.QuitMsg{}, cmd())
+
+			payload, _ := os.ReadFile(out)
+			assert.Equal(t, strings.Join(testRun.expectedEvents, "\n")+"\n", string(payload))
+		})
+	}
+}
+
+func Test_ChangeHookError(t *testing.T) {
+	setChangeHooks(t, "echo failing >&2; exit 1")
+	saveStringToFile(initialDiskData)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = runBatch(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}))
+
+	assert.Equal(t, `change hook failed: command 'sh -c "echo failing >&2; exit 1"' exited with code 1: failing`, listModel(m).list.Title)
+
+	projects, err := project.GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []project.Project{
+		{ID: "2", Name: "web", Path: "./"},
+		{ID: "3", Name: "docs", Path: "./"},
+	}, projects)
+}
+
+func Test_TagAppendedToSearchTerm(t *testing.T) {
+	saveStringToFile(initialDiskData)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = search(m, "api")
+	m = press(m, "#")
+	m = press(m, "go")
+
+	assert.Equal(t, "api #go", listModel(m).searchInput.Value())
+}
+
+const missingDiskData = `
+[
+	{
+		"id": "1",
+		"name": "api",
+		"path": "./"
+	},
+	{
+		"id": "2",
+		"name": "web",
+		"path": "not-a-valid-path"
+	},
+	{
+		"id": "3",
+		"name": "docs",
+		"path": "./",
+		"folders": ["not-a-valid-path"]
+	}
+]
+`
+
+func Test_MissingProjects(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		act      func(m tea.Model) tea.Model
+
+		expectedTitle    string
+		expectedProjects []project.Project
+	}{
+		{
+			testName: "relocate missing project",
+			act: func(m tea.Model) tea.Model {
+				m = pressKey(m, tea.KeyDown)
+				m = press(m, "r")
+				m = pressKey(m, tea.KeyCtrlU)
+				m = press(m, "../")
+				return pressKey(m, tea.KeyEnter)
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: ".."},
+				{ID: "3", Name: "docs", Path: "./", Folders: []string{"not-a-valid-path"}, Missing: true},
+			},
+		},
+		{
+			testName: "relocate to the path of another project",
+			act: func(m tea.Model) tea.Model {
+				m = pressKey(m, tea.KeyDown)
+				m = press(m, "r")
+				m = pressKey(m, tea.KeyCtrlU)
+				m = press(m, ".")
+				return pressKey(m, tea.KeyEnter)
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "not-a-valid-path", Missing: true},
+				{ID: "3", Name: "docs", Path: "./", Folders: []string{"not-a-valid-path"}, Missing: true},
+			},
+		},
+		{
+			testName: "relocate to another missing path",
+			act: func(m tea.Model) tea.Model {
+				m = pressKey(m, tea.KeyDown)
+				m = press(m, "r")
+				m = press(m, "-too")
+				return pressKey(m, tea.KeyEnter)
+			},
+
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "not-a-valid-path", Missing: true},
+				{ID: "3", Name: "docs", Path: "./", Folders: []string{"not-a-valid-path"}, Missing: true},
+			},
+		},
+		{
+			testName: "removing missing projects asks for confirmation",
+			act: func(m tea.Model) tea.Model {
+				return press(m, "D")
+			},
+
+			expectedTitle: "remove 2 missing project(s)? (y/n)",
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "not-a-valid-path", Missing: true},
+				{ID: "3", Name: "docs", Path: "./", Folders: []string{"not-a-valid-path"}, Missing: true},
+			},
+		},
+		{
+			testName: "remove missing projects once confirmed",
+			act: func(m tea.Model) tea.Model {
+				return press(press(m, "D"), "y")
+			},
+
+			expectedTitle: "2 missing project(s) removed",
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+			},
+		},
+		{
+			testName: "cancel removing missing projects",
+			act: func(m tea.Model) tea.Model {
+				return press(press(m, "D"), "n")
+			},
+
+			expectedTitle: listInitialTitle,
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "not-a-valid-path", Missing: true},
+				{ID: "3", Name: "docs", Path: "./", Folders: []string{"not-a-valid-path"}, Missing: true},
+			},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(missingDiskData)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+			assert.Equal(t, "2 project(s) not found, press r to relocate or D to remove", listModel(m).list.Title)
+
+			m = testRun.act(m)
+			if testRun.expectedTitle != "" {
+				assert.Equal(t, testRun.expectedTitle, listModel(m).list.Title)
+			}
+
+			projects, err := project.GetAll()
+			assert.Nil(t, err)
+			assert.Equal(t, testRun.expectedProjects, projects)
+		})
+	}
+}
+
+func Test_DeleteSelection(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		answer   string
+
+		expectedTitle    string
+		expectedProjects []project.Project
+	}{
+		{
+			testName: "deleting the selection asks for confirmation",
+			answer:   "",
+
+			expectedTitle: "delete 2 selected project(s)? (y/n)",
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "./"},
+				{ID: "3", Name: "docs", Path: "./"},
+			},
+		},
+		{
+			testName: "delete the selection once confirmed",
+			answer:   "y",
+
+			expectedTitle: "2 project(s) deleted",
+			expectedProjects: []project.Project{
+				{ID: "3", Name: "docs", Path: "./"},
+			},
+		},
+		{
+			testName: "cancel deleting the selection",
+			answer:   "n",
+
+			expectedTitle: listInitialTitle,
+			expectedProjects: []project.Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "./"},
+				{ID: "3", Name: "docs", Path: "./"},
+			},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(initialDiskData)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+			m = press(m, "x")
+			m = press(m, "x")
+			m = press(m, "d")
+			if testRun.answer != "" {
+				m = press(m, testRun.answer)
+			}
+
+			assert.Equal(t, testRun.expectedTitle, listModel(m).list.Title)
+
+			projects, err := project.GetAll()
+			assert.Nil(t, err)
+			assert.Equal(t, testRun.expectedProjects, projects)
+		})
+	}
+}
+
+func Test_SelectedPath(t *testing.T) {
+	dir := t.TempDir()
+	workspaceFile := filepath.Join(dir, "example"+project.WorkspaceExtension)
+	os.WriteFile(workspaceFile, []byte(`{"folders": []}`), 0644)
+
+	testRuns := []struct {
+		testName string
+		path     string
+
+		expectedPath string
+	}{
+		{
+			testName: "directory",
+			path:     dir,
+
+			expectedPath: dir,
+		},
+		{
+			testName: "workspace file",
+			path:     workspaceFile,
+
+			expectedPath: dir,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(`[{"id": "1", "name": "api", "path": "` + testRun.path + `"}]`)
+
+			m := NewProjectList(true)
+			m, _ = m.Update(m.Init()())
+			m = pressKey(m, tea.KeyEnter)
+
+			assert.Equal(t, testRun.expectedPath, listModel(m).SelectedPath())
+		})
+	}
+}
+
+func Test_RunCommand(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "api",
+			"path": "./",
+			"env": {"GREETING": "hello"},
+			"commands": [
+				{"name": "lint", "run": "true"},
+				{"name": "test", "run": "echo $GREETING; pwd; echo failed >&2; exit 3"}
+			]
+		}
+	]
+	`)
+	wd, _ := os.Getwd()
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}))
+	m = pressKey(m, tea.KeyDown)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = m.Update(cmd())
+	for listModel(m).runner.Running() {
+		m, cmd = m.Update(cmd())
+	}
+
+	runner := listModel(m).runner
+	assert.Equal(t, []string{"hello", wd, "failed"}, runner.Output())
+	var launcherErr *launcher.Error
+	assert.ErrorAs(t, runner.Err(), &launcherErr)
+	assert.Equal(t, 3, launcherErr.ExitCode)
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(cmd())
+	assert.Nil(t, listModel(m).runner)
+}
+
+func Test_StopCommand(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "api",
+			"path": "./",
+			"commands": [
+				{"name": "serve", "run": "sleep 30 & echo $!; wait"}
+			]
+		}
+	]
+	`)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}))
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = m.Update(cmd())
+	m, _ = m.Update(cmd())
+
+	output := listModel(m).runner.Output()
+	assert.Len(t, output, 1)
+	pid, err := strconv.Atoi(output[0])
+	assert.Nil(t, err)
+	assert.True(t, processRunning(pid))
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(cmd())
+	assert.Nil(t, listModel(m).runner)
+
+	assert.Eventually(t, func() bool { return !processRunning(pid) }, 2*time.Second, 10*time.Millisecond)
+}
+
+func Test_RunTask(t *testing.T) {
+	if _, err := exec.LookPath("make"); err != nil {
+		t.Skip("make is not installed")
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "Makefile"), []byte("build:\n\t@echo building\n"), 0644)
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "api",
+			"path": "` + dir + `",
+			"commands": [{"name": "lint", "run": "true"}]
+		}
+	]
+	`)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}))
+	assert.Equal(t, []project.Command{{Name: "lint", Run: "true"}, {Name: "build", Run: "make build"}}, listModel(m).commands)
+
+	m = pressKey(m, tea.KeyDown)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = m.Update(cmd())
+	for listModel(m).runner.Running() {
+		m, cmd = m.Update(cmd())
+	}
+
+	assert.Equal(t, []string{"building"}, listModel(m).runner.Output())
+	assert.Nil(t, listModel(m).runner.Err())
+}
+
+func Test_RunCommandWithBrokenTaskFile(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"scripts": [`), 0644)
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "api",
+			"path": "` + dir + `",
+			"commands": [{"name": "lint", "run": "echo linting"}]
+		}
+	]
+	`)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}))
+	assert.Equal(t, []project.Command{{Name: "lint", Run: "echo linting"}}, listModel(m).commands)
+	assert.Contains(t, m.View(), "error reading tasks from '"+filepath.Join(dir, "package.json")+"'")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = m.Update(cmd())
+	for listModel(m).runner.Running() {
+		m, cmd = m.Update(cmd())
+	}
+
+	assert.Equal(t, []string{"linting"}, listModel(m).runner.Output())
+}
+
+func Test_OpenWithHooks(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		hooks    string
+
+		expectedOutput   []string
+		expectedOpened   bool
+		expectedPaneOpen bool
+	}{
+		{
+			testName: "hooks succeed",
+			hooks:    `{"preOpen": ["echo fetching"], "postOpen": ["echo done"]}`,
+
+			expectedOutput:   nil,
+			expectedOpened:   true,
+			expectedPaneOpen: false,
+		},
+		{
+			testName: "pre-open hook fails with the abort policy",
+			hooks:    `{"preOpen": ["echo fetching; exit 2", "echo never"]}`,
+
+			expectedOutput:   []string{"$ echo fetching; exit 2", "fetching"},
+			expectedOpened:   false,
+			expectedPaneOpen: true,
+		},
+		{
+			testName: "pre-open hook fails with the warn policy",
+			hooks:    `{"preOpen": ["exit 2"], "postOpen": ["echo done"], "onFailure": "warn"}`,
+
+			expectedOutput:   []string{"$ exit 2", "command 'sh -c \"exit 2\"' exited with code 2", "opening 'api'...", "$ echo done", "done"},
+			expectedOpened:   true,
+			expectedPaneOpen: true,
+		},
+		{
+			testName: "pre-open hook fails with the ignore policy",
+			hooks:    `{"preOpen": ["exit 2"], "onFailure": "ignore"}`,
+
+			expectedOutput:   nil,
+			expectedOpened:   true,
+			expectedPaneOpen: false,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(`
+			[
+				{
+					"id": "1",
+					"name": "api",
+					"path": "./",
+					"launcher": {"command": "true"},
+					"hooks": ` + testRun.hooks + `
+				}
+			]
+			`)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+
+			m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			for listModel(m).runner != nil && listModel(m).runner.Running() {
+				m, cmd = m.Update(cmd())
+			}
+
+			runner := listModel(m).runner
+			assert.Equal(t, testRun.expectedPaneOpen, runner != nil)
+			if runner != nil {
+				assert.Equal(t, testRun.expectedOutput, runner.Output())
+
+				// closing the pane quits if the project was opened despite the failure
+				m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+				m, cmd = m.Update(cmd())
+				m, cmd = m.Update(cmd())
+				assert.Nil(t, listModel(m).runner)
+			}
+
+			assert.Equal(t, testRun.expectedOpened, len(listModel(m).choices) == 1)
+			if testRun.expectedOpened {
+				assert.IsType(t, tea.QuitMsg{}, cmd())
+			}
+		})
+	}
+}
+
+func Test_CloseWhileOpeningWithHooks(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		apiHooks string
+		waitFor  string
+
+		expectedLaunches string
+		expectedOpened   []string
+	}{
+		{
+			testName: "closed during pre-open hook",
+			apiHooks: `{"preOpen": ["sleep 30"], "onFailure": "ignore"}`,
+			waitFor:  "$ sleep 30",
+
+			expectedLaunches: "",
+			expectedOpened:   nil,
+		},
+		{
+			testName: "closed during post-open hook",
+			apiHooks: `{"postOpen": ["sleep 30"]}`,
+			waitFor:  "$ sleep 30",
+
+			expectedLaunches: "api\n",
+			expectedOpened:   []string{"api"},
+		},
+		{
+			testName: "closed while launching",
+			apiHooks: `{"preOpen": ["true"]}`,
+			waitFor:  "opening 'api'...",
+
+			expectedLaunches: "api\n",
+			expectedOpened:   []string{"api"},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "launches")
+			t.Setenv("OUT", out)
+			saveStringToFile(`
+			[
+				{
+					"id": "1",
+					"name": "api",
+					"path": "./",
+					"launcher": {"command": "sh -c 'sleep 0.1; echo {name} >> $OUT'"},
+					"hooks": ` + testRun.apiHooks + `
+				},
+				{
+					"id": "2",
+					"name": "web",
+					"path": "./",
+					"launcher": {"command": "sh -c 'echo {name} >> $OUT'"}
+				}
+			]
+			`)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+			m = press(m, "x")
+			m = press(m, "x")
+
+			m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			for !slices.Contains(listModel(m).runner.Output(), testRun.waitFor) {
+				m, cmd = m.Update(cmd())
+			}
+
+			m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+			m, cmd = m.Update(cmd())
+			assert.Nil(t, listModel(m).runner)
+			m, cmd = m.Update(cmd())
+
+			launches, _ := os.ReadFile(out)
+			assert.Equal(t, testRun.expectedLaunches, string(launches))
+
+			var opened []string
+			for _, p := range listModel(m).choices {
+				opened = append(opened, p.Name)
+			}
+			assert.Equal(t, testRun.expectedOpened, opened)
+			if testRun.expectedOpened != nil {
+				assert.IsType(t, tea.QuitMsg{}, cmd())
+			} else {
+				assert.Nil(t, cmd)
+			}
+		})
+	}
+}
+
+func Test_OpenSelection(t *testing.T) {
+	testRuns := []struct {
+		testName    string
+		apiLauncher string
+		webLauncher string
+
+		expectedLaunches string
+		expectedTitle    string
+		expectedOpened   []string
+		expectedRecorded []string
+	}{
+		{
+			testName:    "launch fails after opening a project",
+			apiLauncher: `{"command": "sh -c 'echo {name} >> $OUT'"}`,
+			webLauncher: `{"command": "false"}`,
+
+			expectedLaunches: "api\n",
+			expectedTitle:    "error opening 'web', already opened: api",
+			expectedOpened:   nil,
+			expectedRecorded: []string{"api"},
+		},
+		{
+			testName:    "several tmux sessions to attach",
+			apiLauncher: `{"mode": "tmux"}`,
+			webLauncher: `{"mode": "tmux"}`,
+
+			expectedLaunches: "",
+			expectedTitle:    "only one project can be opened in the terminal at a time, open them one by one",
+			expectedOpened:   nil,
+			expectedRecorded: nil,
+		},
+		{
+			testName:    "tmux session attached last",
+			apiLauncher: `{"mode": "tmux"}`,
+			webLauncher: `{"command": "sh -c 'echo {name} >> $OUT'"}`,
+
+			expectedLaunches: "web\ntmux has-session\n",
+			expectedTitle:    "2 project(s) selected",
+			expectedOpened:   []string{"web", "api"},
+			expectedRecorded: []string{"api", "web"},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			dir := t.TempDir()
+			out := filepath.Join(dir, "launches")
+			t.Setenv("OUT", out)
+			t.Setenv("TMUX", "")
+			os.WriteFile(filepath.Join(dir, "tmux"), []byte("#!/bin/sh\necho \"tmux $1\" >> \"$OUT\"\n"), 0755)
+			t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+			saveStringToFile(`
+			[
+				{"id": "1", "name": "api", "path": "./", "launcher": ` + testRun.apiLauncher + `},
+				{"id": "2", "name": "web", "path": "./tests", "launcher": ` + testRun.webLauncher + `}
+			]
+			`)
+
+			m := NewProjectList(false)
+			m, _ = m.Update(m.Init()())
+			m = press(m, "x")
+			m = press(m, "x")
+			m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			if cmd != nil {
+				m = runCmd(m, cmd)
+			}
+
+			launches, _ := os.ReadFile(out)
+			assert.Equal(t, testRun.expectedLaunches, string(launches))
+			assert.Equal(t, testRun.expectedTitle, listModel(m).list.Title)
+
+			var opened []string
+			for _, p := range listModel(m).choices {
+				opened = append(opened, p.Name)
+			}
+			assert.Equal(t, testRun.expectedOpened, opened)
+
+			projects, err := project.GetAll()
+			assert.Nil(t, err)
+			var recorded []string
+			for _, p := range projects {
+				if p.OpenCount > 0 {
+					recorded = append(recorded, p.Name)
+				}
+			}
+			assert.Equal(t, testRun.expectedRecorded, recorded)
+		})
+	}
+}
+
+func Test_PartialLaunchKeepsUsage(t *testing.T) {
+	saveStringToFile(`
+	[
+		{"id": "1", "name": "api", "path": "./", "launcher": {"command": "true"}},
+		{"id": "2", "name": "web", "path": "./tests", "launcher": {"command": "false"}}
+	]
+	`)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m = press(m, "x")
+	m = press(m, "x")
+	m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	assert.Equal(t, "error opening 'web', already opened: api", listModel(m).list.Title)
+
+	// pinning api right after saves the item shown in the list
+	m = pressKey(m, tea.KeyUp)
+	m = press(m, "p")
+
+	projects, err := project.GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, "api", projects[0].Name)
+	assert.True(t, projects[0].Pinned)
+	assert.Equal(t, 1, projects[0].OpenCount)
+	assert.NotNil(t, projects[0].LastOpened)
+}
+
+func Test_KeysIgnoredWhileLaunching(t *testing.T) {
+	saveStringToFile(initialDiskData)
+
+	m := NewProjectList(false)
+	m, _ = m.Update(m.Init()())
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	// the launchers run in the returned command, not started here
+	m = press(m, "d")
+	projects, err := project.GetAll()
+	assert.Nil(t, err)
+	assert.Len(t, projects, 3)
+
+	opened := []project.Project{selectedProject(m)}
+	m, cmd = m.Update(launchedMsg{projects: opened})
+	assert.Equal(t, opened, listModel(m).choices)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+// search replaces the search term with the given term then submits it.
+func search(m tea.Model, term string) tea.Model {
+	m = press(m, "/")
+	m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyCtrlU}))
+	for _, r := range term {
+		m = runCmd(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}))
+	}
+	return runCmd(m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+}
+
+// runCmd updates the model with the message returned by the command.
+func runCmd(m tea.Model, cmd tea.Cmd) tea.Model {
+	m, _ = m.Update(cmd())
+	return m
+}
+
+// processRunning returns true if the process exists and isn't a zombie waiting to be reaped.
+func processRunning(pid int) bool {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return syscall.Kill(pid, 0) == nil
+	}
+	_, state, _ := strings.Cut(string(stat), ") ")
+	return !strings.HasPrefix(state, "Z")
+}
+
+// runBatch updates the model with the messages returned by the command, running each command of a batch.
+func runBatch(m tea.Model, cmd tea.Cmd) tea.Model {
+	if cmd == nil {
+		return m
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			m = runBatch(m, c)
+		}
+		return m
+	}
+	m, _ = m.Update(msg)
+	return m
+}
+
+// setChangeHooks replaces the change hooks of the config with the given command lines for the duration of the test.
+func setChangeHooks(t *testing.T, commandLines ...string) {
+	previous := changeHooks
+	changeHooks = func() []string { return commandLines }
+	t.Cleanup(func() { changeHooks = previous })
+}
+
+// press sends the runes as a key press.
+func press(m tea.Model, runes string) tea.Model {
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(runes)})
+	return m
+}
+
+// pressKey sends the key press.
+func pressKey(m tea.Model, keyType tea.KeyType) tea.Model {
+	m, _ = m.Update(tea.KeyMsg{Type: keyType})
+	return m
+}
+
+// listModel returns the project list model whether it was returned as a value or a pointer.
+func listModel(m tea.Model) Model {
+	if p, ok := m.(*Model); ok {
+		return *p
+	}
+	return m.(Model)
+}
+
+// selectedProject returns the project under the list's cursor.
+func selectedProject(m tea.Model) project.Project {
+	p, _ := listModel(m).list.SelectedItem().(project.Project)
+	return p
+}
+
+func saveStringToFile(data string) error {
+	return os.WriteFile(config.GetInstance().ProjectsPath, []byte(data), os.ModePerm)
+}
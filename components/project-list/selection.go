@@ -0,0 +1,43 @@
+package projectlist
+
+import (
+	"fmt"
+
+	"ls-projects/models/project"
+)
+
+// toggleSelection adds the project to the selection or removes it if it is already selected.
+func (m *Model) toggleSelection(p project.Project) {
+	if m.selected[p.ID] {
+		delete(m.selected, p.ID)
+	} else {
+		m.selected[p.ID] = true
+	}
+
+	if len(m.selected) == 0 {
+		resetListTitle(m)
+		return
+	}
+
+	m.list.Styles.Title = Style.SelectionTitleStyle
+	m.list.Title = fmt.Sprintf("%d project(s) selected", len(m.selected))
+}
+
+// clearSelection empties the selection and resets the list's title.
+func (m *Model) clearSelection() {
+	for key := range m.selected {
+		delete(m.selected, key)
+	}
+	resetListTitle(m)
+}
+
+// selectedProjects returns the selected projects, in the projects file's order.
+func (m Model) selectedProjects() []project.Project {
+	var projects []project.Project
+	for _, p := range m.onDiskProjects {
+		if m.selected[p.ID] {
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
@@ -0,0 +1,107 @@
+package projectlist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ls-projects/models/project"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openSet opens every member project of the set with its own launcher, then quits the app.
+func (m *Model) openSet(s project.Set) tea.Cmd {
+	projects, err := s.Projects(m.projects())
+	if err != nil {
+		return func() tea.Msg { return launchErrorMsg{name: s.Name, err: err} }
+	}
+	if len(projects) == 0 {
+		return func() tea.Msg { return launchErrorMsg{name: s.Name, err: errors.New("the set has no member")} }
+	}
+
+	return m.openProjects(projects, resolveLaunchers(projects))
+}
+
+// promptSetName shows the input naming a new set made of the selected projects.
+// If renamedSet is not nil, the input renames this set instead.
+func (m *Model) promptSetName(renamedSet *project.Set) tea.Cmd {
+	t := textinput.New()
+	t.Placeholder = "Set name..."
+	t.Cursor.Style = Style.SetNameCursorStyle
+	if renamedSet != nil {
+		t.SetValue(renamedSet.Name)
+	}
+
+	m.setNameInput = &t
+	m.renamedSet = renamedSet
+
+	return m.setNameInput.Focus()
+}
+
+// handleSetNameInput handles the key messages while the set name input is shown.
+func (m *Model) handleSetNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc":
+		m.setNameInput = nil
+		m.renamedSet = nil
+		resetListTitle(m)
+		return m, nil
+
+	case "enter":
+		return m, m.submitSetName()
+	}
+
+	var cmd tea.Cmd
+	*m.setNameInput, cmd = m.setNameInput.Update(msg)
+	return m, cmd
+}
+
+// submitSetName saves the new set or renames the set being renamed.
+// On error, the input stays open so the name can be fixed.
+func (m *Model) submitSetName() tea.Cmd {
+	name := strings.TrimSpace(m.setNameInput.Value())
+
+	var sets []project.Set
+	var err error
+	if m.renamedSet != nil {
+		s := *m.renamedSet
+		s.Name = name
+		sets, err = project.UpdateSet(m.renamedSet.Name, s)
+	} else {
+		projects := m.selectedProjects()
+		members := make([]string, len(projects))
+		for i, p := range projects {
+			members[i] = p.ID
+		}
+		sets, err = project.SaveSet(project.Set{Name: name, Members: members})
+	}
+
+	if err != nil {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = err.Error()
+		return nil
+	}
+
+	isRenaming := m.renamedSet != nil
+	m.setNameInput = nil
+	m.renamedSet = nil
+	cmd := m.setSets(sets)
+
+	m.clearSelection()
+	m.list.Styles.Title = Style.SuccessTitleStyle
+	if isRenaming {
+		m.list.Title = fmt.Sprintf("set '%s' renamed!", name)
+	} else {
+		m.list.Title = fmt.Sprintf("set '%s' saved!", name)
+	}
+
+	return cmd
+}
+
+// setSets replaces the sets shown after the projects in the list.
+func (m *Model) setSets(sets []project.Set) tea.Cmd {
+	m.sets = sets
+	return m.refreshItems()
+}
@@ -18,6 +18,7 @@ var Style = struct {
 	SuccessTitleStyle    lipgloss.Style
 	ErrorTitleStyle      lipgloss.Style
 	MovingModeTitleStyle lipgloss.Style
+	SelectionTitleStyle  lipgloss.Style
 	NoItemsStyle         lipgloss.Style
 	PaginationStyle      lipgloss.Style
 	HelpStyle            lipgloss.Style
@@ -25,11 +26,15 @@ var Style = struct {
 	QuitTextStyleSub     lipgloss.Style
 	FatalErrorStyle      lipgloss.Style
 	PathTextStyle        lipgloss.Style
+	ErrorDetailsStyle    lipgloss.Style
+	SetNameInputStyle    lipgloss.Style
+	SetNameCursorStyle   lipgloss.Style
 }{
 	TitleStyle:           styles.BaseTitle().Background(lipgloss.Color("#6C91BF")),
 	SuccessTitleStyle:    styles.BaseTitle().Background(lipgloss.Color("#25A065")),
 	ErrorTitleStyle:      styles.BaseTitle().Background(lipgloss.Color("#E84855")),
 	MovingModeTitleStyle: styles.BaseTitle().Background(lipgloss.Color("#4d4d4d")),
+	SelectionTitleStyle:  styles.BaseTitle().Background(lipgloss.Color("#8E6CBF")),
 	NoItemsStyle:         list.DefaultStyles().NoItems.MarginLeft(4),
 	PaginationStyle:      list.DefaultStyles().PaginationStyle.PaddingLeft(4),
 	HelpStyle:            list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1),
@@ -37,4 +42,7 @@ var Style = struct {
 	QuitTextStyleSub:     lipgloss.NewStyle().Padding(1, 2).Italic(true).Faint(true),
 	FatalErrorStyle:      lipgloss.NewStyle().Margin(1, 2).Foreground(lipgloss.Color("#E84855")),
 	PathTextStyle:        lipgloss.NewStyle().Foreground(lipgloss.Color("170")),
+	ErrorDetailsStyle:    lipgloss.NewStyle().MarginLeft(4).MarginBottom(1).Foreground(lipgloss.Color("#E84855")),
+	SetNameInputStyle:    lipgloss.NewStyle().Padding(0, 1).MarginLeft(4).Border(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("#8E6CBF")),
+	SetNameCursorStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#8E6CBF")),
 }
@@ -1,46 +1,424 @@
 package projectlist
 
 import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	searchinput "ls-projects/components/search-input"
+	"ls-projects/models/config"
+	"ls-projects/models/launcher"
 	"ls-projects/models/project"
 
 	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
-// castToListItem takes a list of 'Project's and returns it as a casted list of tea's interface 'list.Item'.
-func castToListItem(projects []project.Project) []list.Item {
-	castedItems := make([]list.Item, len(projects))
-	for i, p := range projects {
-		castedItems[i] = p
+// castToListItem takes a list of 'Project's, 'Group's and 'Set's and returns them as a casted list of tea's interface 'list.Item'.
+// Pinned projects are listed first, followed by the projects outside of any group, each group's header and projects, then by the sets.
+// The projects and subgroups of collapsed groups are left out, as well as the groups only containing pinned projects.
+func castToListItem(projects []project.Project, groups []project.Group, sets []project.Set) []list.Item {
+	castedItems := make([]list.Item, 0, len(projects)+len(groups)+len(sets))
+	for _, p := range projects {
+		if p.Pinned {
+			castedItems = append(castedItems, p)
+		}
+	}
+	for _, p := range projects {
+		if p.Group == "" && !p.Pinned {
+			castedItems = append(castedItems, p)
+		}
+	}
+
+	var collapsed *project.Group
+	for _, g := range groups {
+		if collapsed != nil && collapsed.Contains(g.Name) {
+			continue
+		}
+
+		h := groupHeader{Group: g}
+		for _, p := range projects {
+			if g.Contains(p.Group) && !p.Pinned {
+				h.count++
+			}
+		}
+		if h.count == 0 {
+			continue
+		}
+		castedItems = append(castedItems, h)
+
+		if g.Collapsed {
+			collapsed = &g
+			continue
+		}
+
+		for _, p := range projects {
+			if p.Group == g.Name && !p.Pinned {
+				castedItems = append(castedItems, p)
+			}
+		}
+	}
+
+	for _, s := range sets {
+		castedItems = append(castedItems, s)
 	}
 	return castedItems
 }
 
+// castToSortedListItem takes a list of sorted 'Project's and a list of 'Set's and returns them as a casted list of tea's interface 'list.Item'.
+// Pinned projects are listed first, followed by the other projects regardless of their group, then by the sets.
+func castToSortedListItem(projects []project.Project, sets []project.Set) []list.Item {
+	castedItems := make([]list.Item, 0, len(projects)+len(sets))
+	for _, p := range projects {
+		if p.Pinned {
+			castedItems = append(castedItems, p)
+		}
+	}
+	for _, p := range projects {
+		if !p.Pinned {
+			castedItems = append(castedItems, p)
+		}
+	}
+	for _, s := range sets {
+		castedItems = append(castedItems, s)
+	}
+	return castedItems
+}
+
+// setProjects replaces the projects shown in the list, reloading the sets and groups since they depend on projects.
+func (m *Model) setProjects(projects []project.Project) tea.Cmd {
+	if sets, err := project.GetSets(); err == nil {
+		m.sets = sets
+	}
+	if groups, err := project.GetGroups(); err == nil {
+		m.groups = groups
+	}
+
+	m.onDiskProjects = projects
+	return m.refreshItems()
+}
+
+// refreshItems rebuilds the list's items from the projects, groups and sets according to the sort mode.
+func (m *Model) refreshItems() tea.Cmd {
+	m.updateDelegate()
+
+	if m.sortMode == config.FrecencySort {
+		m.items = castToSortedListItem(project.SortByFrecency(m.onDiskProjects, time.Now()), m.sets)
+	} else {
+		m.items = castToListItem(m.onDiskProjects, m.groups, m.sets)
+	}
+
+	// the search is run again so its results match the updated items
+	if m.searchInput != nil {
+		m.searchInput.SetItems(m.searchItems())
+		return m.filterList(m.searchInput.FilteredItems())
+	}
+	return m.list.SetItems(m.items)
+}
+
+// projectByID returns the project with the given ID, false if not found.
+func (m Model) projectByID(id string) (project.Project, bool) {
+	for _, p := range m.onDiskProjects {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return project.Project{}, false
+}
+
+// toggleSortMode switches between the manual and frecency sort modes.
+func (m *Model) toggleSortMode() tea.Cmd {
+	if m.sortMode == config.FrecencySort {
+		m.sortMode = config.ManualSort
+		m.list.Title = "sorted manually"
+	} else {
+		m.sortMode = config.FrecencySort
+		m.list.Title = "sorted by frecency"
+	}
+	m.list.Styles.Title = Style.SuccessTitleStyle
+
+	cmd := m.refreshItems()
+	m.list.Select(0)
+	return cmd
+}
+
+// projects returns every project, in the projects file's order, including the ones hidden in collapsed groups.
+func (m Model) projects() []project.Project {
+	return m.onDiskProjects
+}
+
+// insertionID returns the ID of the project after which a new project is saved: the selected project, or none to save it at the end.
+func (m Model) insertionID() string {
+	if p, ok := m.list.SelectedItem().(project.Project); ok {
+		return p.ID
+	}
+	return ""
+}
+
+// resolveLaunchers returns the launcher of each project, falling back on the global launcher.
+func resolveLaunchers(projects []project.Project) []config.Launcher {
+	global := config.GetInstance().GetLauncher()
+	launchers := make([]config.Launcher, len(projects))
+	for i, p := range projects {
+		launchers[i] = launcher.Resolve(global, p)
+	}
+	return launchers
+}
+
 // resetListTitle resets the initial style and text of the list's title.
 func resetListTitle(m *Model) {
+	m.launchError = nil
 	m.list.Styles.Title = Style.TitleStyle
 	m.list.Title = listInitialTitle
 }
 
 // disableMovingMode resets required value to disable the moving mode.
 func disableMovingMode(m *Model) {
-	m.movingModeInitialIndex = -1
+	m.movedProjectID = ""
 	m.movingModeActive = false
-	m.list.SetDelegate(itemDelegate{movingModeInitialIndex: -1})
+	m.updateDelegate()
 	resetListTitle(m)
 }
 
-// filterList filters the items in the list (m.list) given a list of indices.
-func (m *Model) filterList(filteredIndices []int) {
+// updateDelegate sets the list's delegate according to the current moving mode, selection, sort mode and projects.
+func (m *Model) updateDelegate() {
+	names := make(map[string]string, len(m.onDiskProjects))
+	for _, p := range m.onDiskProjects {
+		names[p.ID] = p.Name
+	}
+
+	m.list.SetDelegate(itemDelegate{
+		movedProjectID: m.movedProjectID,
+		selected:       m.selected,
+		grouped:        m.sortMode != config.FrecencySort,
+		names:          names,
+	})
+}
+
+// searchableItems returns the items searched through: every project, including the ones in collapsed groups, followed by the sets.
+func (m Model) searchableItems() []list.Item {
+	items := make([]list.Item, 0, len(m.onDiskProjects)+len(m.sets))
+	for _, p := range m.onDiskProjects {
+		items = append(items, p)
+	}
+	for _, s := range m.sets {
+		items = append(items, s)
+	}
+	return items
+}
+
+// searchItems returns the searchable items as items of the search input.
+func (m Model) searchItems() []searchinput.Item {
+	items := m.searchableItems()
+	searchItems := make([]searchinput.Item, len(items))
+	for i, item := range items {
+		searchItems[i] = searchinput.Item{Text: item.FilterValue()}
+		if p, ok := item.(project.Project); ok {
+			searchItems[i].Text = strings.TrimSpace(p.Name + " " + p.Description)
+			searchItems[i].Tags = p.Tags
+		}
+	}
+	return searchItems
+}
+
+// filterList filters the items in the list (m.list) given a list of indices in the searchable items.
+// Matching pinned projects are listed first, keeping the search's ranking otherwise.
+func (m *Model) filterList(filteredIndices []int) tea.Cmd {
 	if filteredIndices == nil {
-		m.list.SetItems(m.items)
-		return
+		return m.list.SetItems(m.items)
+	}
+
+	items := m.searchableItems()
+	filteredItems := make([]list.Item, 0, len(filteredIndices))
+	for _, itemIndex := range filteredIndices {
+		if itemIndex < len(items) {
+			filteredItems = append(filteredItems, items[itemIndex])
+		}
+	}
+
+	slices.SortStableFunc(filteredItems, func(a, b list.Item) int {
+		if isPinned(a) == isPinned(b) {
+			return 0
+		} else if isPinned(a) {
+			return -1
+		}
+		return 1
+	})
+	return m.list.SetItems(filteredItems)
+}
+
+// togglePin pins the project if it isn't pinned, unpins it otherwise.
+func (m *Model) togglePin(p project.Project) tea.Cmd {
+	p.Pinned = !p.Pinned
+
+	projects, err := project.UpdateByID(p.ID, p)
+	if err != nil {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = fmt.Sprintf("error pinning project '%s'", p.Name)
+		return nil
+	}
+
+	cmd := m.setProjects(projects)
+
+	m.list.Styles.Title = Style.SuccessTitleStyle
+	if p.Pinned {
+		m.list.Title = fmt.Sprintf("project '%s' pinned", p.Name)
+	} else {
+		m.list.Title = fmt.Sprintf("project '%s' unpinned", p.Name)
+	}
+
+	return tea.Batch(cmd, notifyChange(launcher.Event{Type: launcher.ProjectUpdated, Project: p}))
+}
+
+// isPinned returns true if the item is a pinned project.
+func isPinned(item list.Item) bool {
+	p, ok := item.(project.Project)
+	return ok && p.Pinned
+}
+
+// openProject opens the project with the given launcher, then quits the app.
+func (m *Model) openProject(p project.Project, l config.Launcher) tea.Cmd {
+	return m.openProjects([]project.Project{p}, []config.Launcher{l})
+}
+
+// openProjects opens the projects in order, each with the launcher at the same index, then quits the app once launchedMsg is received.
+// Only one launcher can need the terminal, its project being opened last so the app quits once its foreground command exits.
+// If a launcher fails, the remaining projects are not opened, the app keeps running and the error is shown under the list.
+// If hooks are defined for the projects, they are opened by openWithHooks instead.
+func (m *Model) openProjects(projects []project.Project, launchers []config.Launcher) tea.Cmd {
+	for _, p := range projects {
+		if p.Missing {
+			return func() tea.Msg { return launchErrorMsg{name: p.Name, err: errMissingProject} }
+		}
+	}
+
+	projects, launchers, ok := foregroundLast(projects, launchers)
+	if !ok {
+		m.list.Styles.Title = Style.ErrorTitleStyle
+		m.list.Title = "only one project can be opened in the terminal at a time, open them one by one"
+		return nil
+	}
+
+	if hasHooks(projects) {
+		return m.openWithHooks(projects, launchers)
+	}
+
+	// the launchers run outside of the event loop, the keys being ignored until they're done
+	m.launching = true
+	return func() tea.Msg {
+		var foreground *exec.Cmd
+		for i, p := range projects {
+			cmd, err := launcher.Open(launchers[i], p)
+			if err != nil {
+				return launchErrorMsg{name: p.Name, err: err, opened: projects[:i]}
+			}
+			if cmd != nil {
+				foreground = cmd
+			}
+		}
+		return launchedMsg{projects, foreground}
+	}
+}
+
+// foregroundLast moves the project whose launcher needs the terminal to the end, keeping the order of the others.
+// Returns false if more than one launcher needs the terminal.
+func foregroundLast(projects []project.Project, launchers []config.Launcher) ([]project.Project, []config.Launcher, bool) {
+	index := -1
+	for i, l := range launchers {
+		if !launcher.NeedsTerminal(l) {
+			continue
+		}
+		if index >= 0 {
+			return nil, nil, false
+		}
+		index = i
+	}
+
+	if index < 0 || index == len(projects)-1 {
+		return projects, launchers, true
+	}
+
+	orderedProjects := append(slices.Delete(slices.Clone(projects), index, index+1), projects[index])
+	orderedLaunchers := append(slices.Delete(slices.Clone(launchers), index, index+1), launchers[index])
+	return orderedProjects, orderedLaunchers, true
+}
+
+// quitAfterOpening records that the projects were opened then quits the app,
+// once the foreground command of the last project exits if its launcher needs the terminal.
+func (m *Model) quitAfterOpening(projects []project.Project, foreground *exec.Cmd) tea.Cmd {
+	m.choices = projects
+	m.recordOpen(projects)
+
+	if foreground != nil {
+		p := projects[len(projects)-1]
+		return tea.ExecProcess(foreground, func(err error) tea.Msg {
+			if err != nil {
+				return launchErrorMsg{name: p.Name, err: launcher.WrapError(foreground, err)}
+			}
+			return quit()
+		})
 	}
 
-	filteredItems := make([]list.Item, len(filteredIndices))
-	for i, itemIndex := range filteredIndices {
-		if itemIndex <= len(m.items) {
-			filteredItems[i] = m.items[itemIndex]
+	return quit
+}
+
+// recordOpen records that the projects were opened now.
+// Errors are ignored since tracking usage must not prevent opening projects.
+func (m *Model) recordOpen(projects []project.Project) {
+	now := time.Now()
+	for _, p := range projects {
+		if updatedProjects, err := project.RecordOpen(p.ID, now); err == nil {
+			m.onDiskProjects = updatedProjects
 		}
 	}
-	m.list.SetItems(filteredItems)
+}
+
+// deleteSelection deletes the selected projects from the disk, then clears the selection.
+func (m *Model) deleteSelection() tea.Cmd {
+	projects := m.selectedProjects()
+
+	for i, p := range projects {
+		updatedProjects, err := project.DeleteByID(p.ID)
+		if err != nil {
+			cmd := m.setProjects(m.onDiskProjects)
+
+			m.list.Styles.Title = Style.ErrorTitleStyle
+			m.list.Title = fmt.Sprintf("error deleting project '%s'", p.Name)
+			// the projects deleted before the failure are still notified
+			return tea.Batch(cmd, notifyChange(changeEvents(launcher.ProjectDeleted, projects[:i])...))
+		}
+
+		m.onDiskProjects = updatedProjects
+	}
+
+	cmd := m.setProjects(m.onDiskProjects)
+	m.clearSelection()
+
+	m.list.Styles.Title = Style.SuccessTitleStyle
+	m.list.Title = fmt.Sprintf("%d project(s) deleted", len(projects))
+
+	return tea.Batch(cmd, notifyChange(changeEvents(launcher.ProjectDeleted, projects)...))
+}
+
+// launchErrorDetails returns the command line, exit code and stderr of a failed launch.
+func launchErrorDetails(err error) string {
+	var launcherErr *launcher.Error
+	if !errors.As(err, &launcherErr) {
+		return err.Error()
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "command: %s\n", launcherErr.Command)
+	if launcherErr.ExitCode < 0 {
+		fmt.Fprintf(&sb, "failed to start: %s", launcherErr.Err)
+	} else {
+		fmt.Fprintf(&sb, "exit code: %d", launcherErr.ExitCode)
+	}
+	if launcherErr.Stderr != "" {
+		fmt.Fprintf(&sb, "\n\n%s", launcherErr.Stderr)
+	}
+	return sb.String()
 }
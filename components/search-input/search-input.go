@@ -1,17 +1,43 @@
 package searchinput
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/sahilm/fuzzy"
 )
 
+// An Item is an entry searched by its text and filtered by its tags.
+type Item struct {
+	Text string
+	Tags []string
+}
+
+// hasTags returns true if the item has every given tag, ignoring case.
+func (i Item) hasTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range i.Tags {
+			if strings.EqualFold(t, tag) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 type Model struct {
 	input           textinput.Model
-	unfilteredItems []string
+	unfilteredItems []Item
 }
 
-func NewSearchInput(items []string) Model {
+func NewSearchInput(items []Item) Model {
 	t := textinput.New()
 	t.Placeholder = "Search..."
 	t.Cursor.Style = Style.InputCursorStyle
@@ -57,19 +83,71 @@ func (m *Model) Focus() tea.Cmd {
 	return m.input.Focus()
 }
 
+// SetItems replaces the searched items, keeping the search term.
+func (m *Model) SetItems(items []Item) {
+	m.unfilteredItems = items
+}
+
+// FilteredItems returns the indices of the items matching the search term, nil if the search term is empty.
+func (m Model) FilteredItems() []int {
+	return m.getFilteredItems()
+}
+
+// Value returns the search term entered in the text input.
+func (m Model) Value() string {
+	return m.input.Value()
+}
+
+// SetValue sets the search term entered in the text input.
+func (m *Model) SetValue(v string) {
+	m.input.SetValue(v)
+	m.input.CursorEnd()
+}
+
 // getFilteredItems returns the indices in the unfiltered list of items that are a fuzzy match
 // with the search term entered in the text input.
+// Words starting with '#' are tags every matched item must have.
 //
 // Returns nil if the search term is empty and an empty list if not match is found.
 func (m Model) getFilteredItems() []int {
-	if m.input.Value() == "" {
+	if strings.TrimSpace(m.input.Value()) == "" {
 		return nil
 	}
 
-	matches := fuzzy.Find(m.input.Value(), m.unfilteredItems)
+	var tags, words []string
+	for _, word := range strings.Fields(m.input.Value()) {
+		if strings.HasPrefix(word, "#") {
+			if tag := strings.TrimPrefix(word, "#"); tag != "" {
+				tags = append(tags, tag)
+			}
+		} else {
+			words = append(words, word)
+		}
+	}
+
+	var candidates []int
+	for i, item := range m.unfilteredItems {
+		if item.hasTags(tags) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	if len(words) == 0 {
+		if candidates == nil {
+			return []int{}
+		}
+		return candidates
+	}
+
+	texts := make([]string, len(candidates))
+	for i, itemIndex := range candidates {
+		texts[i] = m.unfilteredItems[itemIndex].Text
+	}
+
+	matches := fuzzy.Find(strings.Join(words, " "), texts)
 	itemIndices := make([]int, matches.Len())
 	for i, match := range matches {
-		itemIndices[i] = match.Index
+		itemIndices[i] = candidates[match.Index]
 	}
 
 	return itemIndices
@@ -0,0 +1,81 @@
+package searchinput
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FilteredItems(t *testing.T) {
+	items := []Item{
+		{Text: "api", Tags: []string{"Go", "client-a"}},
+		{Text: "web", Tags: []string{"ts", "client-a"}},
+		{Text: "worker", Tags: []string{"go"}},
+		{Text: "docs"},
+	}
+
+	testRuns := []struct {
+		testName   string
+		searchTerm string
+
+		expectedIndices []int
+	}{
+		{
+			testName:   "empty search term",
+			searchTerm: "  ",
+
+			expectedIndices: nil,
+		},
+		{
+			testName:   "single tag ignoring case",
+			searchTerm: "#go",
+
+			expectedIndices: []int{0, 2},
+		},
+		{
+			testName:   "multiple tags",
+			searchTerm: "#go #CLIENT-A",
+
+			expectedIndices: []int{0},
+		},
+		{
+			testName:   "tag and fuzzy words",
+			searchTerm: "#go wrk",
+
+			expectedIndices: []int{2},
+		},
+		{
+			testName:   "fuzzy words before tag",
+			searchTerm: "wb #client-a",
+
+			expectedIndices: []int{1},
+		},
+		{
+			testName:   "empty tag",
+			searchTerm: "#",
+
+			expectedIndices: []int{0, 1, 2, 3},
+		},
+		{
+			testName:   "unknown tag",
+			searchTerm: "#rust",
+
+			expectedIndices: []int{},
+		},
+		{
+			testName:   "tag without fuzzy match",
+			searchTerm: "#go docs",
+
+			expectedIndices: []int{},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			m := NewSearchInput(items)
+			m.SetValue(testRun.searchTerm)
+
+			assert.Equal(t, testRun.expectedIndices, m.FilteredItems())
+		})
+	}
+}
@@ -1,17 +1,87 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"ls-projects/commands/check"
+	shellinit "ls-projects/commands/shell-init"
 	projectlist "ls-projects/components/project-list"
+	"ls-projects/models/config"
+	"ls-projects/models/project"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
+var printPath = flag.Bool("print-path", false, "print the selected project's path to stdout instead of opening it")
+
 func main() {
-	p := tea.NewProgram(projectlist.NewProjectList())
-	if _, err := p.Run(); err != nil {
-		fmt.Println(err)
+	flag.Parse()
+
+	if flag.Arg(0) == "shell-init" {
+		runShellInit(flag.Arg(1))
+		return
+	}
+
+	if flag.Arg(0) == "check" {
+		runCheck()
+		return
+	}
+
+	var opts []tea.ProgramOption
+	if *printPath {
+		// stdout is reserved for the selected path, the UI is drawn on stderr
+		// the default renderer is updated in place since the package-level styles already reference it
+		lipgloss.DefaultRenderer().SetOutput(termenv.NewOutput(os.Stderr))
+		opts = append(opts, tea.WithOutput(os.Stderr))
+	}
+
+	p := tea.NewProgram(projectlist.NewProjectList(*printPath), opts...)
+	m, err := p.Run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *printPath {
+		// the final model is either the list itself or a pointer to it
+		l, ok := m.(interface{ SelectedPath() string })
+		if !ok || l.SelectedPath() == "" {
+			os.Exit(1)
+		}
+		fmt.Println(l.SelectedPath())
+	}
+}
+
+// runShellInit prints the wrapper function for the given shell or exits on error.
+func runShellInit(shell string) {
+	binary, err := os.Executable()
+	if err != nil {
+		binary = os.Args[0]
+	}
+
+	script, err := shellinit.Script(shell, binary)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(script)
+}
+
+// runCheck prints every problem found in the projects file, exiting with an error if the file can't be loaded.
+func runCheck() {
+	path := config.GetInstance().ProjectsPath
+	problems, err := project.Check()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	report, valid := check.Report(path, problems)
+	fmt.Print(report)
+	if !valid {
 		os.Exit(1)
 	}
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/marcantoineg/fileutil"
 )
@@ -33,7 +34,7 @@ func initConfig() *Config {
 	if exists := fileutil.Exists(configPath); exists {
 		return readOnDiskConfig(configPath)
 	} else {
-		fmt.Printf("file '%s' does not exists. creating...\n", configPath)
+		fmt.Fprintf(os.Stderr, "file '%s' does not exists. creating...\n", configPath)
 		err := fileutil.CreateEmptyFile(configPath)
 		if err != nil {
 			panic(err)
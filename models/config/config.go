@@ -3,6 +3,12 @@ package config
 
 import "github.com/marcantoineg/fileutil"
 
+// Sort modes of the projects list.
+const (
+	ManualSort   = "manual"
+	FrecencySort = "frecency"
+)
+
 const (
 	appDataPath          = "~/.config/ls-projects"
 	projectsFileName     = ".projects.json"
@@ -18,6 +24,18 @@ type Config struct {
 
 	// absolute path to the config file
 	ConfigPath string `json:"configPath"`
+
+	// command used to open projects, defaults to VS Code when omitted
+	Launcher *Launcher `json:"launcher,omitempty"`
+
+	// additional launchers listed in the "open with" menu
+	Actions []Action `json:"actions,omitempty"`
+
+	// commands run around the launch of every project
+	Hooks *Hooks `json:"hooks,omitempty"`
+
+	// initial sort mode of the projects list, either "manual" or "frecency", defaults to "manual"
+	SortMode string `json:"sortMode,omitempty"`
 }
 
 // saveToDisk saves the config to the file
@@ -172,6 +172,46 @@ func Test_initConfig(t *testing.T) {
 	}
 }
 
+func TestGetLauncher(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		config   Config
+
+		expectedLauncher Launcher
+	}{
+		{
+			testName: "no launcher expects default launcher",
+			config:   Config{},
+
+			expectedLauncher: DefaultLauncher,
+		},
+		{
+			testName: "launcher without command expects default launcher",
+			config:   Config{Launcher: &Launcher{RunInProjectDir: false}},
+
+			expectedLauncher: DefaultLauncher,
+		},
+		{
+			testName: "tmux launcher without command",
+			config:   Config{Launcher: &Launcher{Mode: TmuxMode}},
+
+			expectedLauncher: Launcher{Mode: TmuxMode},
+		},
+		{
+			testName: "custom launcher",
+			config:   Config{Launcher: &Launcher{Command: "nvim {path}", RunInProjectDir: true}},
+
+			expectedLauncher: Launcher{Command: "nvim {path}", RunInProjectDir: true},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedLauncher, testRun.config.GetLauncher())
+		})
+	}
+}
+
 func saveStringToFile(filePath, data string) error {
 	return os.WriteFile(filePath, []byte(data), os.ModePerm)
 }
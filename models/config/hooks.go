@@ -0,0 +1,44 @@
+package config
+
+// Policies applied when a hook fails.
+const (
+	// AbortOnFailure stops opening the project when a pre-open hook fails
+	AbortOnFailure = "abort"
+
+	// WarnOnFailure keeps opening the project, showing the failure before quitting
+	WarnOnFailure = "warn"
+
+	// IgnoreOnFailure keeps opening the project as if the hook succeeded
+	IgnoreOnFailure = "ignore"
+)
+
+// Hooks are shell commands run from the project's directory around its launch.
+type Hooks struct {
+	// commands run before the project is opened, e.g. "git fetch"
+	PreOpen []string `json:"preOpen,omitempty"`
+
+	// commands run once the launcher returned
+	PostOpen []string `json:"postOpen,omitempty"`
+
+	// policy applied when a hook fails, either "abort", "warn" or "ignore", defaults to "abort"
+	OnFailure string `json:"onFailure,omitempty"`
+
+	// commands run after a project is added, updated, deleted or reordered, receiving the change as JSON on stdin
+	// only read from the config since they don't depend on a project
+	OnChange []string `json:"onChange,omitempty"`
+}
+
+// IsEmpty returns true if no pre-open nor post-open hook is defined.
+func (h Hooks) IsEmpty() bool {
+	return len(h.PreOpen) == 0 && len(h.PostOpen) == 0
+}
+
+// Policy returns the policy applied when a hook fails, AbortOnFailure if none or an unknown one is defined.
+func (h Hooks) Policy() string {
+	switch h.OnFailure {
+	case WarnOnFailure, IgnoreOnFailure:
+		return h.OnFailure
+	default:
+		return AbortOnFailure
+	}
+}
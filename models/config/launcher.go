@@ -0,0 +1,81 @@
+package config
+
+import "strings"
+
+// TmuxMode is the launcher mode creating or attaching a tmux session named after the project.
+const TmuxMode = "tmux"
+
+// A Launcher describes the command used to open a project.
+type Launcher struct {
+	// command template, supports the {path}, {name} and {target} placeholders
+	// where {target} is the project's workspace file, generated if the project has additional folders, or its path
+	Command string `json:"command"`
+
+	// whether the command is run from within the project's directory
+	RunInProjectDir bool `json:"runInProjectDir"`
+
+	// optional mode replacing the command, only "tmux" is supported
+	Mode string `json:"mode,omitempty"`
+
+	// whether the command needs the terminal, e.g. a terminal editor, the app quitting once it exits
+	Terminal bool `json:"terminal,omitempty"`
+
+	// command template used for projects on a remote host, supports the {host}, {path} and {name} placeholders
+	// defaults to the command itself if it has a {host} placeholder, and to VS Code's remote command for the default launcher
+	RemoteCommand string `json:"remoteCommand,omitempty"`
+}
+
+// An Action is a named launcher listed in the "open with" menu.
+type Action struct {
+	Name string `json:"name"`
+	Launcher
+}
+
+// DefaultLauncher opens a project in a new window of VS Code.
+var DefaultLauncher = Launcher{
+	Command:         "code -n {target}",
+	RunInProjectDir: true,
+	RemoteCommand:   "code --remote ssh-remote+{host} {path}",
+}
+
+// IsEmpty returns true if the launcher defines neither a command nor a mode.
+func (l Launcher) IsEmpty() bool {
+	return l.Command == "" && l.Mode == ""
+}
+
+// String returns a short description of what the launcher runs.
+func (l Launcher) String() string {
+	if l.Mode == TmuxMode {
+		return "tmux session"
+	}
+	return l.Command
+}
+
+// RemoteTemplate returns the command template used to open remote projects, empty if the launcher can't open them.
+// Other launchers can't fall back to the default remote command since it would open another editor than the chosen one.
+func (l Launcher) RemoteTemplate() string {
+	switch {
+	case l.RemoteCommand != "":
+		return l.RemoteCommand
+	case strings.Contains(l.Command, "{host}"):
+		return l.Command
+	case l.Command == DefaultLauncher.Command:
+		return DefaultLauncher.RemoteCommand
+	default:
+		return ""
+	}
+}
+
+// SupportsRemote returns true if the launcher can open projects on a remote host.
+// The tmux mode only creates local sessions, so it never can.
+func (l Launcher) SupportsRemote() bool {
+	return l.Mode != TmuxMode && l.RemoteTemplate() != ""
+}
+
+// GetLauncher returns the launcher defined in the config or the default one if none is defined.
+func (c Config) GetLauncher() Launcher {
+	if c.Launcher == nil || c.Launcher.IsEmpty() {
+		return DefaultLauncher
+	}
+	return *c.Launcher
+}
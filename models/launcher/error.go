@@ -0,0 +1,130 @@
+package launcher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// An Error describes a launcher command that failed to start or exited with a non-zero code.
+type Error struct {
+	// command line of the failed command
+	Command string
+
+	// exit code of the command, -1 if it didn't start
+	ExitCode int
+
+	// output of the command on stderr
+	Stderr string
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.ExitCode < 0 {
+		return fmt.Sprintf("command '%s' failed to start: %s", e.Command, e.Err)
+	}
+
+	msg := fmt.Sprintf("command '%s' exited with code %d", e.Command, e.ExitCode)
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// run runs the command, capturing its stderr.
+// If the command fails, the returned error is an *Error.
+func run(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return newError(cmd, err, stderr.String())
+	}
+	return nil
+}
+
+// launchGracePeriod is how long a launcher is waited for before it is considered started,
+// e.g. a terminal emulator running until its window is closed.
+const launchGracePeriod = 500 * time.Millisecond
+
+// launch starts the command in its own process group and waits for it to exit for launchGracePeriod at most,
+// so long-running launchers don't block the app while the ones failing right away are still reported.
+// Its stderr is written to an unlinked temporary file rather than a pipe, so the command can outlive the app.
+// If the command fails, the returned error is an *Error.
+func launch(cmd *exec.Cmd) error {
+	stderr, err := os.CreateTemp("", "ls-projects-stderr-*")
+	if err != nil {
+		return newError(cmd, err, "")
+	}
+	os.Remove(stderr.Name())
+	defer stderr.Close()
+
+	cmd.Stderr = stderr
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return newError(cmd, err, "")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			stderr.Seek(0, io.SeekStart)
+			output, _ := io.ReadAll(stderr)
+			return newError(cmd, err, string(output))
+		}
+		return nil
+	case <-time.After(launchGracePeriod):
+		return nil
+	}
+}
+
+// newError wraps the error returned by a command into an *Error.
+func newError(cmd *exec.Cmd, err error, stderr string) *Error {
+	exitCode := -1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+
+	return &Error{
+		Command:  commandLine(cmd),
+		ExitCode: exitCode,
+		Stderr:   strings.TrimSpace(stderr),
+		Err:      err,
+	}
+}
+
+// WrapError wraps an error returned by a command run outside of this package, e.g. a foreground command.
+// Returns nil if err is nil.
+func WrapError(cmd *exec.Cmd, err error) error {
+	if err == nil {
+		return nil
+	}
+	return newError(cmd, err, "")
+}
+
+// commandLine returns the command's arguments joined by spaces, quoting the ones containing spaces.
+func commandLine(cmd *exec.Cmd) string {
+	args := make([]string, len(cmd.Args))
+	for i, arg := range cmd.Args {
+		if strings.ContainsAny(arg, " \t\n\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,62 @@
+package launcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"time"
+
+	"ls-projects/models/project"
+)
+
+// Types of the events passed to the change hooks.
+const (
+	ProjectAdded     = "add"
+	ProjectUpdated   = "update"
+	ProjectDeleted   = "delete"
+	ProjectReordered = "reorder"
+)
+
+// An Event describes a change of the projects list, passed as JSON on the stdin of the change hooks.
+type Event struct {
+	Type    string          `json:"event"`
+	Project project.Project `json:"project"`
+}
+
+// changeHookTimeout is how long a change hook can run before being killed.
+const changeHookTimeout = 30 * time.Second
+
+// RunChangeHooks runs the hooks' command lines with `sh -c` for each event in order, passing the event as JSON on stdin.
+// Every hook runs even if one fails, the failures being returned together as *Error.
+func RunChangeHooks(commandLines []string, events ...Event) error {
+	var errs []error
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		for _, commandLine := range commandLines {
+			errs = append(errs, runChangeHook(commandLine, payload))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runChangeHook runs a single change hook with the payload on stdin, killing it after changeHookTimeout.
+func runChangeHook(commandLine string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), changeHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", commandLine)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.WaitDelay = streamWaitDelay
+
+	err := run(cmd)
+	if errors.Is(err, exec.ErrWaitDelay) {
+		return nil
+	}
+	return err
+}
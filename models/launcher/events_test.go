@@ -0,0 +1,78 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunChangeHooks(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		hooks    []string
+		events   []Event
+
+		expectedPayload string
+		expectedErrors  int
+	}{
+		{
+			testName: "event passed on stdin",
+			hooks:    []string{"cat >> \"$OUT\""},
+			events:   []Event{{Type: ProjectAdded, Project: project.Project{ID: "1", Name: "api", Path: "~/code/api"}}},
+
+			expectedPayload: `{"event":"add","project":{"id":"1","name":"api","path":"~/code/api"}}`,
+			expectedErrors:  0,
+		},
+		{
+			testName: "hooks run for each event",
+			hooks:    []string{"cat >> \"$OUT\"; echo >> \"$OUT\""},
+			events: []Event{
+				{Type: ProjectDeleted, Project: project.Project{ID: "1", Name: "api", Path: "./"}},
+				{Type: ProjectDeleted, Project: project.Project{ID: "2", Name: "web", Path: "./"}},
+			},
+
+			expectedPayload: `{"event":"delete","project":{"id":"1","name":"api","path":"./"}}` + "\n" +
+				`{"event":"delete","project":{"id":"2","name":"web","path":"./"}}` + "\n",
+			expectedErrors: 0,
+		},
+		{
+			testName: "failing hook doesn't stop the others",
+			hooks:    []string{"echo failing >&2; exit 3", "cat >> \"$OUT\"", "exit 1"},
+			events:   []Event{{Type: ProjectReordered, Project: project.Project{ID: "1", Name: "api", Path: "./"}}},
+
+			expectedPayload: `{"event":"reorder","project":{"id":"1","name":"api","path":"./"}}`,
+			expectedErrors:  2,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "events")
+			t.Setenv("OUT", out)
+
+			err := RunChangeHooks(testRun.hooks, testRun.events...)
+
+			payload, _ := os.ReadFile(out)
+			assert.Equal(t, testRun.expectedPayload, string(payload))
+
+			if testRun.expectedErrors == 0 {
+				assert.Nil(t, err)
+				return
+			}
+
+			var joined interface{ Unwrap() []error }
+			assert.True(t, errors.As(err, &joined))
+			assert.Len(t, joined.Unwrap(), testRun.expectedErrors)
+
+			var launcherErr *Error
+			assert.True(t, errors.As(err, &launcherErr))
+			assert.Equal(t, 3, launcherErr.ExitCode)
+			assert.Equal(t, "failing", launcherErr.Stderr)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"ls-projects/models/config"
+	"ls-projects/models/project"
+)
+
+// ResolveHooks returns the hooks run around the project's launch: the global hooks followed by the project's own hooks.
+// The project's failure policy takes precedence over the global one.
+func ResolveHooks(global *config.Hooks, p project.Project) config.Hooks {
+	var hooks config.Hooks
+	for _, h := range []*config.Hooks{global, p.Hooks} {
+		if h == nil {
+			continue
+		}
+
+		hooks.PreOpen = append(hooks.PreOpen, h.PreOpen...)
+		hooks.PostOpen = append(hooks.PostOpen, h.PostOpen...)
+		if h.OnFailure != "" {
+			hooks.OnFailure = h.OnFailure
+		}
+	}
+	return hooks
+}
+
+// RunHooks runs the hooks' command lines in order with `sh -c` from the project's directory, writing their output to w.
+// With the abort policy, the first failure stops the remaining hooks and is returned.
+// With the warn policy, every hook runs and their failures are returned together.
+// With the ignore policy, failures are only written to w.
+func RunHooks(ctx context.Context, p project.Project, commandLines []string, policy string, w io.Writer) error {
+	var errs []error
+	for _, commandLine := range commandLines {
+		fmt.Fprintf(w, "$ %s\n", commandLine)
+
+		err := Stream(ctx, Shell(p, commandLine), w)
+		if err == nil {
+			continue
+		}
+		if ctx.Err() != nil || policy == config.AbortOnFailure {
+			return err
+		}
+
+		fmt.Fprintf(w, "%s\n", err)
+		if policy == config.WarnOnFailure {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,135 @@
+package launcher
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"ls-projects/models/config"
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResolveHooks(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		global   *config.Hooks
+		project  project.Project
+
+		expectedHooks  config.Hooks
+		expectedPolicy string
+	}{
+		{
+			testName: "no hook",
+			global:   nil,
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedHooks:  config.Hooks{},
+			expectedPolicy: config.AbortOnFailure,
+		},
+		{
+			testName: "global hooks only",
+			global:   &config.Hooks{PreOpen: []string{"git fetch"}, OnFailure: config.WarnOnFailure},
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedHooks:  config.Hooks{PreOpen: []string{"git fetch"}, OnFailure: config.WarnOnFailure},
+			expectedPolicy: config.WarnOnFailure,
+		},
+		{
+			testName: "project hooks after global ones",
+			global:   &config.Hooks{PreOpen: []string{"git fetch"}, OnFailure: config.WarnOnFailure},
+			project: project.Project{Name: "example-project", Path: "./", Hooks: &config.Hooks{
+				PreOpen:   []string{"direnv allow"},
+				PostOpen:  []string{"docker network create dev"},
+				OnFailure: config.IgnoreOnFailure,
+			}},
+
+			expectedHooks: config.Hooks{
+				PreOpen:   []string{"git fetch", "direnv allow"},
+				PostOpen:  []string{"docker network create dev"},
+				OnFailure: config.IgnoreOnFailure,
+			},
+			expectedPolicy: config.IgnoreOnFailure,
+		},
+		{
+			testName: "unknown policy",
+			global:   &config.Hooks{PreOpen: []string{"git fetch"}, OnFailure: "retry"},
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedHooks:  config.Hooks{PreOpen: []string{"git fetch"}, OnFailure: "retry"},
+			expectedPolicy: config.AbortOnFailure,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			hooks := ResolveHooks(testRun.global, testRun.project)
+
+			assert.Equal(t, testRun.expectedHooks, hooks)
+			assert.Equal(t, testRun.expectedPolicy, hooks.Policy())
+		})
+	}
+}
+
+func Test_RunHooks(t *testing.T) {
+	hooks := []string{"echo first", "echo failing >&2; exit 3", "echo last"}
+
+	testRuns := []struct {
+		testName string
+		policy   string
+
+		expectedOutput string
+		expectErr      bool
+	}{
+		{
+			testName: "abort",
+			policy:   config.AbortOnFailure,
+
+			expectedOutput: "$ echo first\nfirst\n$ echo failing >&2; exit 3\nfailing\n",
+			expectErr:      true,
+		},
+		{
+			testName: "warn",
+			policy:   config.WarnOnFailure,
+
+			expectedOutput: "$ echo first\nfirst\n$ echo failing >&2; exit 3\nfailing\n" +
+				"command 'sh -c \"echo failing >&2; exit 3\"' exited with code 3\n$ echo last\nlast\n",
+			expectErr: true,
+		},
+		{
+			testName: "ignore",
+			policy:   config.IgnoreOnFailure,
+
+			expectedOutput: "$ echo first\nfirst\n$ echo failing >&2; exit 3\nfailing\n" +
+				"command 'sh -c \"echo failing >&2; exit 3\"' exited with code 3\n$ echo last\nlast\n",
+			expectErr: false,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			var output bytes.Buffer
+			err := RunHooks(context.Background(), project.Project{Name: "example-project", Path: "./"}, hooks, testRun.policy, &output)
+
+			assert.Equal(t, testRun.expectedOutput, output.String())
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_RunHooksCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var output bytes.Buffer
+	err := RunHooks(ctx, project.Project{Name: "example-project", Path: "./"}, []string{"sleep 5", "echo never"}, config.IgnoreOnFailure, &output)
+
+	assert.NotNil(t, err)
+	assert.False(t, strings.Contains(output.String(), "never"))
+}
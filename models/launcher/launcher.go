@@ -0,0 +1,176 @@
+// Package launcher implements functions required to open a project with a configured command.
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+
+	"ls-projects/models/config"
+	"ls-projects/models/project"
+
+	"github.com/marcantoineg/fileutil"
+)
+
+// Open runs the launcher against the project, waiting for it to exit unless it keeps running past a short grace period.
+// If the launcher needs the terminal (e.g. a terminal editor or attaching a tmux session), the returned command
+// is not started and must be run in the foreground by the caller. Otherwise, the returned command is nil.
+// If a command fails, the second return value is an *Error holding its stderr and exit code.
+func Open(l config.Launcher, p project.Project) (*exec.Cmd, error) {
+	if l.Mode == config.TmuxMode {
+		return openTmuxSession(p)
+	}
+
+	cmd, err := Command(l, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.Terminal {
+		return cmd, nil
+	}
+	return nil, launch(cmd)
+}
+
+// NeedsTerminal returns true if opening a project with the launcher returns a command to run in the foreground,
+// i.e. for terminal launchers and when attaching a tmux session from outside tmux.
+func NeedsTerminal(l config.Launcher) bool {
+	return l.Terminal || (l.Mode == config.TmuxMode && os.Getenv("TMUX") == "")
+}
+
+// Resolve returns the project's own launcher if it defines one, the global launcher otherwise.
+func Resolve(global config.Launcher, p project.Project) config.Launcher {
+	if p.Launcher != nil && !p.Launcher.IsEmpty() {
+		return *p.Launcher
+	}
+	return global
+}
+
+// Actions returns the actions available for the project, starting with the project's default launcher
+// followed by the actions defined in the config. Actions that can't open remote projects are left out for them.
+func Actions(c config.Config, p project.Project) []config.Action {
+	actions := []config.Action{{Name: "default", Launcher: Resolve(c.GetLauncher(), p)}}
+	for _, a := range c.Actions {
+		if !a.IsEmpty() && (!p.IsRemote() || a.SupportsRemote()) {
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+// Command builds the command described by the launcher's template for the given project, run with the project's environment variables.
+// Returns an error if the template is empty or malformed.
+func Command(l config.Launcher, p project.Project) (*exec.Cmd, error) {
+	if p.IsRemote() {
+		return remoteCommand(l, p)
+	}
+
+	args, err := splitArgs(l.Command)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("launcher command is empty")
+	}
+
+	path := fileutil.ReplaceTilde(p.Path)
+	target := path
+	if strings.Contains(l.Command, "{target}") {
+		target, err = workspaceTarget(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r := strings.NewReplacer("{path}", path, "{name}", p.Name, "{target}", target)
+	for i := range args {
+		args[i] = r.Replace(args[i])
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if l.RunInProjectDir {
+		cmd.Dir = ProjectDir(p)
+	}
+	setEnv(cmd, p)
+
+	return cmd, nil
+}
+
+// remoteCommand builds the command described by the launcher's remote template for the given remote project.
+// Returns an error if the launcher can't open remote projects.
+func remoteCommand(l config.Launcher, p project.Project) (*exec.Cmd, error) {
+	template := l.RemoteTemplate()
+	if template == "" {
+		return nil, fmt.Errorf("launcher '%s' can't open remote projects, set its remoteCommand", l)
+	}
+
+	args, err := splitArgs(template)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("launcher remote command is empty")
+	}
+
+	r := strings.NewReplacer("{host}", p.Host, "{path}", p.Path, "{name}", p.Name)
+	for i := range args {
+		args[i] = r.Replace(args[i])
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	setEnv(cmd, p)
+
+	return cmd, nil
+}
+
+// setEnv runs the command with the project's environment variables merged into the current environment.
+// The command inherits the current environment untouched if the project has no variable.
+func setEnv(cmd *exec.Cmd, p project.Project) {
+	if len(p.Env) > 0 {
+		cmd.Env = p.Environ(os.Environ())
+	}
+}
+
+// splitArgs splits a command template into its arguments.
+// Arguments are separated by spaces unless they are surrounded by single or double quotes.
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var sb strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				sb.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, sb.String())
+				sb.Reset()
+				inArg = false
+			}
+		default:
+			sb.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("launcher command has an unterminated quote")
+	}
+	if inArg {
+		args = append(args, sb.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,385 @@
+package launcher
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ls-projects/models/config"
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resolve(t *testing.T) {
+	global := config.Launcher{Command: "code -n {path}", RunInProjectDir: true}
+
+	testRuns := []struct {
+		testName string
+		project  project.Project
+
+		expectedLauncher config.Launcher
+	}{
+		{
+			testName: "project without launcher expects global launcher",
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedLauncher: global,
+		},
+		{
+			testName: "project with empty launcher expects global launcher",
+			project:  project.Project{Name: "example-project", Path: "./", Launcher: &config.Launcher{}},
+
+			expectedLauncher: global,
+		},
+		{
+			testName: "project with launcher expects project launcher",
+			project:  project.Project{Name: "example-project", Path: "./", Launcher: &config.Launcher{Command: "goland {path}"}},
+
+			expectedLauncher: config.Launcher{Command: "goland {path}"},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedLauncher, Resolve(global, testRun.project))
+		})
+	}
+}
+
+func Test_Actions(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		config   config.Config
+		project  project.Project
+
+		expectedActions []config.Action
+	}{
+		{
+			testName: "no action in config expects default launcher only",
+			config:   config.Config{},
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedActions: []config.Action{
+				{Name: "default", Launcher: config.DefaultLauncher},
+			},
+		},
+		{
+			testName: "actions in config expects default launcher first",
+			config: config.Config{
+				Actions: []config.Action{
+					{Name: "terminal", Launcher: config.Launcher{Command: "alacritty", RunInProjectDir: true}},
+					{Name: "empty", Launcher: config.Launcher{}},
+					{Name: "files", Launcher: config.Launcher{Command: "xdg-open {path}"}},
+				},
+			},
+			project: project.Project{Name: "example-project", Path: "./", Launcher: &config.Launcher{Command: "nvim {path}"}},
+
+			expectedActions: []config.Action{
+				{Name: "default", Launcher: config.Launcher{Command: "nvim {path}"}},
+				{Name: "terminal", Launcher: config.Launcher{Command: "alacritty", RunInProjectDir: true}},
+				{Name: "files", Launcher: config.Launcher{Command: "xdg-open {path}"}},
+			},
+		},
+		{
+			testName: "remote project expects actions able to open it",
+			config: config.Config{
+				Actions: []config.Action{
+					{Name: "terminal", Launcher: config.Launcher{Command: "alacritty", RunInProjectDir: true}},
+					{Name: "nvim", Launcher: config.Launcher{Command: "nvim {path}", RemoteCommand: "ssh -t {host} nvim {path}"}},
+					{Name: "tmux", Launcher: config.Launcher{Mode: config.TmuxMode}},
+				},
+			},
+			project: project.Project{Name: "example-project", Path: "/srv/api", Host: "devbox"},
+
+			expectedActions: []config.Action{
+				{Name: "default", Launcher: config.DefaultLauncher},
+				{Name: "nvim", Launcher: config.Launcher{Command: "nvim {path}", RemoteCommand: "ssh -t {host} nvim {path}"}},
+			},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedActions, Actions(testRun.config, testRun.project))
+		})
+	}
+}
+
+func Test_Command(t *testing.T) {
+	homeDir, _ := os.UserHomeDir()
+
+	testRuns := []struct {
+		testName string
+		launcher config.Launcher
+		project  project.Project
+
+		expectedArgs []string
+		expectedDir  string
+		expectErr    bool
+	}{
+		{
+			testName: "default launcher",
+			launcher: config.DefaultLauncher,
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedArgs: []string{"code", "-n", "./"},
+			expectedDir:  "./",
+			expectErr:    false,
+		},
+		{
+			testName: "name and path placeholders",
+			launcher: config.Launcher{Command: "tool --title={name} {path}"},
+			project:  project.Project{Name: "example-project", Path: "/tmp"},
+
+			expectedArgs: []string{"tool", "--title=example-project", "/tmp"},
+			expectedDir:  "",
+			expectErr:    false,
+		},
+		{
+			testName: "path including '~'",
+			launcher: config.Launcher{Command: "nvim {path}", RunInProjectDir: true},
+			project:  project.Project{Name: "example-project", Path: "~/dev"},
+
+			expectedArgs: []string{"nvim", homeDir + "/dev"},
+			expectedDir:  homeDir + "/dev",
+			expectErr:    false,
+		},
+		{
+			testName: "quoted arguments",
+			launcher: config.Launcher{Command: `zed "{path}" 'a b'`},
+			project:  project.Project{Name: "example-project", Path: "/tmp/with space"},
+
+			expectedArgs: []string{"zed", "/tmp/with space", "a b"},
+			expectedDir:  "",
+			expectErr:    false,
+		},
+		{
+			testName: "empty command",
+			launcher: config.Launcher{Command: "  "},
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedArgs: nil,
+			expectErr:    true,
+		},
+		{
+			testName: "unterminated quote",
+			launcher: config.Launcher{Command: `code "{path}`},
+			project:  project.Project{Name: "example-project", Path: "./"},
+
+			expectedArgs: nil,
+			expectErr:    true,
+		},
+		{
+			testName: "remote project with default launcher",
+			launcher: config.DefaultLauncher,
+			project:  project.Project{Name: "example-project", Path: "/srv/api", Host: "devbox"},
+
+			expectedArgs: []string{"code", "--remote", "ssh-remote+devbox", "/srv/api"},
+			expectedDir:  "",
+			expectErr:    false,
+		},
+		{
+			testName: "remote project with remote command",
+			launcher: config.Launcher{Command: "nvim {path}", RemoteCommand: "ssh -t {host} nvim {path}"},
+			project:  project.Project{Name: "example-project", Path: "/srv/api", Host: "devbox"},
+
+			expectedArgs: []string{"ssh", "-t", "devbox", "nvim", "/srv/api"},
+			expectedDir:  "",
+			expectErr:    false,
+		},
+		{
+			testName: "remote project with host placeholder in command",
+			launcher: config.Launcher{Command: "zed ssh://{host}{path}", RunInProjectDir: true},
+			project:  project.Project{Name: "example-project", Path: "/srv/api", Host: "devbox"},
+
+			expectedArgs: []string{"zed", "ssh://devbox/srv/api"},
+			expectedDir:  "",
+			expectErr:    false,
+		},
+		{
+			testName: "remote project with local-only launcher",
+			launcher: config.Launcher{Command: "nvim {path}"},
+			project:  project.Project{Name: "example-project", Path: "/srv/api", Host: "devbox"},
+
+			expectedArgs: nil,
+			expectErr:    true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			cmd, err := Command(testRun.launcher, testRun.project)
+
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, cmd)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testRun.expectedArgs, cmd.Args)
+				assert.Equal(t, testRun.expectedDir, cmd.Dir)
+			}
+		})
+	}
+}
+
+func Test_CommandEnv(t *testing.T) {
+	t.Setenv("LS_PROJECTS_TEST_DIR", "/tmp")
+
+	cmd, err := Command(config.Launcher{Command: "nvim {path}"}, project.Project{Name: "example-project", Path: "./"})
+	assert.Nil(t, err)
+	assert.Nil(t, cmd.Env)
+
+	cmd, err = Command(config.Launcher{Command: "nvim {path}"}, project.Project{
+		Name: "example-project",
+		Path: "./",
+		Env:  map[string]string{"KUBECONFIG": "$LS_PROJECTS_TEST_DIR/kube"},
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, cmd.Env, "KUBECONFIG=/tmp/kube")
+	assert.Contains(t, cmd.Env, "LS_PROJECTS_TEST_DIR=/tmp")
+}
+
+func Test_OpenError(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		launcher config.Launcher
+
+		expectedErr *Error
+	}{
+		{
+			testName: "successful command",
+			launcher: config.Launcher{Command: "true"},
+
+			expectedErr: nil,
+		},
+		{
+			testName: "command exiting with non-zero code",
+			launcher: config.Launcher{Command: "sh -c 'echo {name} failed >&2; exit 3'"},
+
+			expectedErr: &Error{
+				Command:  `sh -c "echo example-project failed >&2; exit 3"`,
+				ExitCode: 3,
+				Stderr:   "example-project failed",
+			},
+		},
+		{
+			testName: "command not found",
+			launcher: config.Launcher{Command: "ls-projects-not-a-command {path}"},
+
+			expectedErr: &Error{
+				Command:  "ls-projects-not-a-command ./",
+				ExitCode: -1,
+				Stderr:   "",
+			},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			_, err := Open(testRun.launcher, project.Project{Name: "example-project", Path: "./"})
+
+			if testRun.expectedErr == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			var launcherErr *Error
+			assert.ErrorAs(t, err, &launcherErr)
+			assert.Equal(t, testRun.expectedErr.Command, launcherErr.Command)
+			assert.Equal(t, testRun.expectedErr.ExitCode, launcherErr.ExitCode)
+			assert.Equal(t, testRun.expectedErr.Stderr, launcherErr.Stderr)
+			assert.NotNil(t, launcherErr.Err)
+		})
+	}
+}
+
+func Test_OpenLongRunning(t *testing.T) {
+	start := time.Now()
+	_, err := Open(config.Launcher{Command: "sleep 3"}, project.Project{Name: "example-project", Path: "./"})
+
+	assert.Nil(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func Test_OpenTerminal(t *testing.T) {
+	logPath := stubBinary(t, "editor", "test -t 0\n")
+	p := project.Project{Name: "example-project", Path: "./"}
+
+	// without a terminal, the editor's stdin is not a tty
+	_, err := Open(config.Launcher{Command: "editor {path}"}, p)
+	var launcherErr *Error
+	assert.ErrorAs(t, err, &launcherErr)
+	assert.Equal(t, 1, launcherErr.ExitCode)
+	os.Remove(logPath)
+
+	cmd, err := Open(config.Launcher{Command: "editor {path}", Terminal: true}, p)
+	assert.Nil(t, err)
+	assert.NotNil(t, cmd)
+	assert.Nil(t, cmd.Process)
+	assert.NoFileExists(t, logPath)
+
+	if _, err := exec.LookPath("script"); err != nil {
+		t.Skip("script is not installed")
+	}
+	// script runs the returned command in a pseudo-terminal like the foreground does
+	err = exec.Command("script", "-qec", commandLine(cmd), os.DevNull).Run()
+	assert.Nil(t, err)
+	assert.FileExists(t, logPath)
+}
+
+func Test_OpenRemote(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		launcher config.Launcher
+		project  project.Project
+
+		expectedCalls []string
+	}{
+		{
+			testName: "default launcher",
+			launcher: config.DefaultLauncher,
+			project:  project.Project{Name: "example-project", Path: "/srv/example-project", Host: "devbox"},
+
+			expectedCalls: []string{"--remote ssh-remote+devbox /srv/example-project"},
+		},
+		{
+			testName: "custom remote command",
+			launcher: config.Launcher{Command: "nvim {path}", RemoteCommand: "code --folder-uri vscode-remote://ssh-remote+{host}{path}"},
+			project:  project.Project{Name: "example-project", Path: "/srv/example-project", Host: "devbox"},
+
+			expectedCalls: []string{"--folder-uri vscode-remote://ssh-remote+devbox/srv/example-project"},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			logPath := stubBinary(t, "code", "")
+
+			foreground, err := Open(testRun.launcher, testRun.project)
+			assert.Nil(t, err)
+			assert.Nil(t, foreground)
+
+			log, _ := os.ReadFile(logPath)
+			assert.Equal(t, testRun.expectedCalls, strings.Split(strings.TrimSpace(string(log)), "\n"))
+		})
+	}
+}
+
+// stubBinary adds a fake binary to the PATH logging its arguments to the returned file, then running the given script.
+func stubBinary(t *testing.T, name string, script string) string {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, name+".log")
+
+	content := "#!/bin/sh\n" +
+		"echo \"$@\" >> '" + logPath + "'\n" +
+		script
+	err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0755)
+	assert.Nil(t, err)
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logPath
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package launcher
+
+import "os/exec"
+
+// setProcessGroup does nothing since process groups are only supported on unix systems.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the started command, the processes it started being left running.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package launcher
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup makes the command start its own process group, so the processes it starts can be killed with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the started command along with every process of its group.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
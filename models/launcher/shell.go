@@ -0,0 +1,82 @@
+package launcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ls-projects/models/project"
+
+	"github.com/marcantoineg/fileutil"
+)
+
+// Shell returns the command running the command line with `sh -c` from the project's directory, with the project's environment variables.
+// Commands of remote projects are run through ssh on the project's host.
+func Shell(p project.Project, commandLine string) *exec.Cmd {
+	if p.IsRemote() {
+		cmd := exec.Command("ssh", p.Host, "cd "+remoteDir(p.Path)+" && "+commandLine)
+		setEnv(cmd, p)
+		return cmd
+	}
+
+	cmd := exec.Command("sh", "-c", commandLine)
+	cmd.Dir = ProjectDir(p)
+	setEnv(cmd, p)
+	return cmd
+}
+
+// streamWaitDelay is how long the output of the processes started by a streamed command is read once it exited.
+const streamWaitDelay = time.Second
+
+// Stream runs the command writing its output on stdout and stderr to w.
+// The command runs in its own process group, killed as a whole if the context is canceled, e.g. a dev server started by `npm run dev`.
+// Processes left running in the background by the command stop being read streamWaitDelay after it exited.
+// If the command fails, the returned error is an *Error.
+func Stream(ctx context.Context, cmd *exec.Cmd, w io.Writer) error {
+	cmd.Stdout = w
+	cmd.Stderr = w
+	cmd.WaitDelay = streamWaitDelay
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return WrapError(cmd, err)
+	}
+
+	stop := context.AfterFunc(ctx, func() { killProcessGroup(cmd) })
+	defer stop()
+
+	err := cmd.Wait()
+	if errors.Is(err, exec.ErrWaitDelay) {
+		return nil
+	}
+	return WrapError(cmd, err)
+}
+
+// ProjectDir returns the directory of the project, the one containing the workspace file if the project's path points at one.
+func ProjectDir(p project.Project) string {
+	path := fileutil.ReplaceTilde(p.Path)
+	if p.IsWorkspaceFile() {
+		return filepath.Dir(path)
+	}
+	return path
+}
+
+// remoteDir returns the quoted path of a remote project's directory, leaving a leading '~' unquoted so the remote shell expands it.
+func remoteDir(path string) string {
+	if path == "~" {
+		return path
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return "~/" + Quote(rest)
+	}
+	return Quote(path)
+}
+
+// Quote surrounds the string by single quotes, escaping the ones it contains.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,57 @@
+package launcher
+
+import (
+	"testing"
+
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Shell(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		project  project.Project
+
+		expectedArgs []string
+		expectedDir  string
+	}{
+		{
+			testName: "local project",
+			project:  project.Project{Name: "example-project", Path: "/tmp"},
+
+			expectedArgs: []string{"sh", "-c", "make test"},
+			expectedDir:  "/tmp",
+		},
+		{
+			testName: "workspace file",
+			project:  project.Project{Name: "example-project", Path: "/tmp/example.code-workspace"},
+
+			expectedArgs: []string{"sh", "-c", "make test"},
+			expectedDir:  "/tmp",
+		},
+		{
+			testName: "remote project",
+			project:  project.Project{Name: "example-project", Path: "/srv/o'neil", Host: "devbox"},
+
+			expectedArgs: []string{"ssh", "devbox", `cd '/srv/o'\''neil' && make test`},
+			expectedDir:  "",
+		},
+		{
+			testName: "remote project in home directory",
+			project:  project.Project{Name: "example-project", Path: "~/code/api", Host: "devbox"},
+
+			expectedArgs: []string{"ssh", "devbox", "cd ~/'code/api' && make test"},
+			expectedDir:  "",
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			cmd := Shell(testRun.project, "make test")
+
+			assert.Equal(t, testRun.expectedArgs, cmd.Args)
+			assert.Equal(t, testRun.expectedDir, cmd.Dir)
+		})
+	}
+}
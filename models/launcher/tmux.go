@@ -0,0 +1,53 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"ls-projects/models/project"
+)
+
+// openTmuxSession creates the project's tmux session with its environment variables if it doesn't exist yet.
+// When already inside tmux, the client is switched to the session and the returned command is nil.
+// Otherwise, the returned command attaches the session and must be run in the foreground.
+func openTmuxSession(p project.Project) (*exec.Cmd, error) {
+	if p.IsRemote() {
+		return nil, fmt.Errorf("project '%s' is on a remote host, which is not supported by the tmux mode", p.Name)
+	}
+
+	session := tmuxSessionName(p.Name)
+	target := "=" + session
+
+	err := run(exec.Command("tmux", "has-session", "-t", target))
+	if err != nil {
+		var launcherErr *Error
+		if errors.As(err, &launcherErr) && launcherErr.ExitCode < 0 {
+			return nil, err
+		}
+
+		args := []string{"new-session", "-d", "-s", session, "-c", ProjectDir(p)}
+		for _, kv := range p.ExpandedEnv(os.Environ()) {
+			args = append(args, "-e", kv)
+		}
+
+		err = run(exec.Command("tmux", args...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if os.Getenv("TMUX") != "" {
+		return nil, run(exec.Command("tmux", "switch-client", "-t", target))
+	}
+
+	cmd := exec.Command("tmux", "attach-session", "-t", target)
+	return cmd, nil
+}
+
+// tmuxSessionName returns the project's name with the characters not allowed by tmux in session names replaced.
+func tmuxSessionName(name string) string {
+	return strings.NewReplacer(".", "_", ":", "_").Replace(name)
+}
@@ -0,0 +1,133 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ls-projects/models/config"
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubTmux adds a fake tmux binary to the PATH logging its arguments to the returned file.
+// The has-session sub-command succeeds only if hasSession is true.
+func stubTmux(t *testing.T, hasSession bool) string {
+	hasSessionExitCode := "1"
+	if hasSession {
+		hasSessionExitCode = "0"
+	}
+
+	return stubBinary(t, "tmux", "if [ \"$1\" = \"has-session\" ]; then exit "+hasSessionExitCode+"; fi\n")
+}
+
+func Test_OpenTmux(t *testing.T) {
+	testRuns := []struct {
+		testName   string
+		project    project.Project
+		hasSession bool
+		insideTmux bool
+
+		expectedCalls      []string
+		expectedForeground []string
+	}{
+		{
+			testName:   "new session outside tmux",
+			project:    project.Project{Name: "example.project", Path: "/tmp"},
+			hasSession: false,
+			insideTmux: false,
+
+			expectedCalls: []string{
+				"has-session -t =example_project",
+				"new-session -d -s example_project -c /tmp",
+			},
+			expectedForeground: []string{"tmux", "attach-session", "-t", "=example_project"},
+		},
+		{
+			testName:   "new session with environment variables",
+			project:    project.Project{Name: "example-project", Path: "/tmp", Env: map[string]string{"KUBECONFIG": "/tmp/kube", "AWS_PROFILE": "dev"}},
+			hasSession: false,
+			insideTmux: false,
+
+			expectedCalls: []string{
+				"has-session -t =example-project",
+				"new-session -d -s example-project -c /tmp -e AWS_PROFILE=dev -e KUBECONFIG=/tmp/kube",
+			},
+			expectedForeground: []string{"tmux", "attach-session", "-t", "=example-project"},
+		},
+		{
+			testName:   "existing session outside tmux",
+			project:    project.Project{Name: "example-project", Path: "/tmp"},
+			hasSession: true,
+			insideTmux: false,
+
+			expectedCalls: []string{
+				"has-session -t =example-project",
+			},
+			expectedForeground: []string{"tmux", "attach-session", "-t", "=example-project"},
+		},
+		{
+			testName:   "new session inside tmux",
+			project:    project.Project{Name: "example-project", Path: "/tmp"},
+			hasSession: false,
+			insideTmux: true,
+
+			expectedCalls: []string{
+				"has-session -t =example-project",
+				"new-session -d -s example-project -c /tmp",
+				"switch-client -t =example-project",
+			},
+			expectedForeground: nil,
+		},
+		{
+			testName:   "new session of a workspace file",
+			project:    project.Project{Name: "example-project", Path: "/tmp/example" + project.WorkspaceExtension},
+			hasSession: false,
+			insideTmux: false,
+
+			expectedCalls: []string{
+				"has-session -t =example-project",
+				"new-session -d -s example-project -c /tmp",
+			},
+			expectedForeground: []string{"tmux", "attach-session", "-t", "=example-project"},
+		},
+		{
+			testName:   "existing session inside tmux",
+			project:    project.Project{Name: "example:project", Path: "/tmp"},
+			hasSession: true,
+			insideTmux: true,
+
+			expectedCalls: []string{
+				"has-session -t =example_project",
+				"switch-client -t =example_project",
+			},
+			expectedForeground: nil,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			logPath := stubTmux(t, testRun.hasSession)
+			if testRun.insideTmux {
+				t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+			} else {
+				t.Setenv("TMUX", "")
+			}
+
+			foreground, err := Open(config.Launcher{Mode: config.TmuxMode}, testRun.project)
+			assert.Nil(t, err)
+
+			log, _ := os.ReadFile(logPath)
+			assert.Equal(t, testRun.expectedCalls, strings.Split(strings.TrimSpace(string(log)), "\n"))
+
+			if testRun.expectedForeground == nil {
+				assert.Nil(t, foreground)
+			} else {
+				assert.Equal(t, testRun.expectedForeground[1:], foreground.Args[1:])
+				assert.Equal(t, testRun.expectedForeground[0], filepath.Base(foreground.Path))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"ls-projects/models/project"
+
+	"github.com/marcantoineg/fileutil"
+)
+
+// workspaceFile is the content of a generated VS Code workspace file.
+type workspaceFile struct {
+	Folders []workspaceFolder `json:"folders"`
+}
+
+type workspaceFolder struct {
+	Path string `json:"path"`
+}
+
+// unsafeFileNameChars matches the characters replaced in generated workspace file names.
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// workspaceTarget returns what VS Code opens for the project: its path, which can be a workspace file,
+// or a generated workspace file if the project has additional folders.
+func workspaceTarget(p project.Project) (string, error) {
+	if p.IsWorkspaceFile() || len(p.Folders) == 0 {
+		return fileutil.ReplaceTilde(p.Path), nil
+	}
+	return generateWorkspace(p)
+}
+
+// generateWorkspace writes a workspace file holding the project's path and additional folders in the temporary directory.
+// The file is named after the project's name, shown by VS Code, and its ID so projects with similar names don't share it.
+// Returns the path to the generated file.
+func generateWorkspace(p project.Project) (string, error) {
+	var ws workspaceFile
+	for _, folder := range append([]string{p.Path}, p.Folders...) {
+		abs, err := filepath.Abs(fileutil.ReplaceTilde(folder))
+		if err != nil {
+			return "", err
+		}
+		ws.Folders = append(ws.Folders, workspaceFolder{Path: abs})
+	}
+
+	dir := filepath.Join(os.TempDir(), "ls-projects")
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+
+	name := p.Name
+	if p.ID != "" {
+		name += "-" + p.ID
+	}
+	path := filepath.Join(dir, unsafeFileNameChars.ReplaceAllString(name, "-")+project.WorkspaceExtension)
+	return path, fileutil.SaveToFile(ws, path)
+}
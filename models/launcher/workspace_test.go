@@ -0,0 +1,93 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ls-projects/models/config"
+	"ls-projects/models/project"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CommandTarget(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+
+	cwd, _ := os.Getwd()
+	parentDir := filepath.Dir(cwd)
+	workspaceFile := filepath.Join(tempDir, "example"+project.WorkspaceExtension)
+	generatedWorkspaceFile := filepath.Join(tempDir, "ls-projects", "example-project-1"+project.WorkspaceExtension)
+
+	testRuns := []struct {
+		testName string
+		project  project.Project
+
+		expectedArgs      []string
+		expectedDir       string
+		expectedWorkspace string
+	}{
+		{
+			testName: "directory",
+			project:  project.Project{Name: "example-project", Path: cwd},
+
+			expectedArgs:      []string{"code", "-n", cwd},
+			expectedDir:       cwd,
+			expectedWorkspace: "",
+		},
+		{
+			testName: "workspace file",
+			project:  project.Project{Name: "example-project", Path: workspaceFile},
+
+			expectedArgs:      []string{"code", "-n", workspaceFile},
+			expectedDir:       tempDir,
+			expectedWorkspace: "",
+		},
+		{
+			testName: "additional folders",
+			project:  project.Project{ID: "1", Name: "example project", Path: "./", Folders: []string{"../"}},
+
+			expectedArgs: []string{"code", "-n", generatedWorkspaceFile},
+			expectedDir:  "./",
+			expectedWorkspace: `{
+  "folders": [
+    {
+      "path": "` + cwd + `"
+    },
+    {
+      "path": "` + parentDir + `"
+    }
+  ]
+}`,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			cmd, err := Command(config.DefaultLauncher, testRun.project)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testRun.expectedArgs, cmd.Args)
+			assert.Equal(t, testRun.expectedDir, cmd.Dir)
+
+			if testRun.expectedWorkspace != "" {
+				content, err := os.ReadFile(generatedWorkspaceFile)
+				assert.Nil(t, err)
+				assert.Equal(t, testRun.expectedWorkspace, string(content))
+			}
+		})
+	}
+}
+
+func Test_GenerateWorkspaceName(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	first, err := generateWorkspace(project.Project{ID: "1", Name: "api v2", Path: "./"})
+	assert.Nil(t, err)
+	second, err := generateWorkspace(project.Project{ID: "2", Name: "api-v2", Path: "./"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "api-v2-1"+project.WorkspaceExtension, filepath.Base(first))
+	assert.Equal(t, "api-v2-2"+project.WorkspaceExtension, filepath.Base(second))
+}
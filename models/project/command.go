@@ -0,0 +1,9 @@
+package project
+
+// A Command is a named shell command run from the project's directory, e.g. the project's tests or dev server.
+type Command struct {
+	Name string `json:"name"`
+
+	// shell command line, run with `sh -c`
+	Run string `json:"run"`
+}
@@ -0,0 +1,44 @@
+package project
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// ExpandedEnv returns the project's environment variables in the "KEY=value" form, sorted by key.
+// References to other variables in the values, e.g. "$HOME/.kube/config" or "${HOME}", are expanded from the base environment,
+// given in the form of os.Environ. Unknown variables expand to an empty string.
+func (p Project) ExpandedEnv(base []string) []string {
+	lookup := map[string]string{}
+	for _, kv := range base {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			lookup[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(p.Env))
+	for key := range p.Env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	env := make([]string, len(keys))
+	for i, key := range keys {
+		env[i] = key + "=" + os.Expand(p.Env[key], func(name string) string { return lookup[name] })
+	}
+	return env
+}
+
+// Environ returns the base environment, given in the form of os.Environ, with the project's expanded variables
+// added to it or replacing the base variables with the same key.
+func (p Project) Environ(base []string) []string {
+	environ := make([]string, 0, len(base)+len(p.Env))
+	for _, kv := range base {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := p.Env[key]; !ok {
+			environ = append(environ, kv)
+		}
+	}
+	return append(environ, p.ExpandedEnv(base)...)
+}
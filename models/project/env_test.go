@@ -0,0 +1,50 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Environ(t *testing.T) {
+	base := []string{"HOME=/home/me", "AWS_PROFILE=default", "PATH=/usr/bin"}
+
+	testRuns := []struct {
+		testName string
+		env      map[string]string
+
+		expectedExpandedEnv []string
+		expectedEnviron     []string
+	}{
+		{
+			testName: "no variable",
+			env:      nil,
+
+			expectedExpandedEnv: []string{},
+			expectedEnviron:     base,
+		},
+		{
+			testName: "new and replaced variables",
+			env:      map[string]string{"GOPRIVATE": "github.com/acme/*", "AWS_PROFILE": "acme"},
+
+			expectedExpandedEnv: []string{"AWS_PROFILE=acme", "GOPRIVATE=github.com/acme/*"},
+			expectedEnviron:     []string{"HOME=/home/me", "PATH=/usr/bin", "AWS_PROFILE=acme", "GOPRIVATE=github.com/acme/*"},
+		},
+		{
+			testName: "references to other variables",
+			env:      map[string]string{"KUBECONFIG": "$HOME/.kube/acme", "PATH": "${HOME}/bin:$PATH", "EMPTY": "$UNKNOWN"},
+
+			expectedExpandedEnv: []string{"EMPTY=", "KUBECONFIG=/home/me/.kube/acme", "PATH=/home/me/bin:/usr/bin"},
+			expectedEnviron:     []string{"HOME=/home/me", "AWS_PROFILE=default", "EMPTY=", "KUBECONFIG=/home/me/.kube/acme", "PATH=/home/me/bin:/usr/bin"},
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			p := Project{Name: "example-project", Path: "./", Env: testRun.env}
+
+			assert.Equal(t, testRun.expectedExpandedEnv, p.ExpandedEnv(base))
+			assert.Equal(t, testRun.expectedEnviron, p.Environ(base))
+		})
+	}
+}
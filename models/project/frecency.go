@@ -0,0 +1,66 @@
+package project
+
+import (
+	"slices"
+	"time"
+)
+
+// Frecency returns a score ranking the project by how often and how recently it was opened, 0 if it was never opened.
+// Each opening counts for more when the project was opened recently.
+func (p Project) Frecency(now time.Time) float64 {
+	if p.LastOpened == nil || p.OpenCount == 0 {
+		return 0
+	}
+
+	var weight float64
+	switch age := now.Sub(*p.LastOpened); {
+	case age < time.Hour:
+		weight = 4
+	case age < 24*time.Hour:
+		weight = 2
+	case age < 7*24*time.Hour:
+		weight = 0.5
+	default:
+		weight = 0.25
+	}
+	return float64(p.OpenCount) * weight
+}
+
+// SortByFrecency returns a copy of the projects sorted by descending frecency, keeping the order of projects with the same score.
+func SortByFrecency(projects []Project, now time.Time) []Project {
+	sorted := slices.Clone(projects)
+	slices.SortStableFunc(sorted, func(a, b Project) int {
+		fa, fb := a.Frecency(now), b.Frecency(now)
+		if fa > fb {
+			return -1
+		} else if fa < fb {
+			return 1
+		}
+		return 0
+	})
+	return sorted
+}
+
+// RecordOpen fetches the projects from the disk, records that the project with the given ID was opened at the given time, then saves the updated list.
+// If no error is encountered, it returns the newly updated projects list. Else it returns the error as the second return value.
+func RecordOpen(id string, t time.Time) ([]Project, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := f.indexOf(id)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Projects[index].LastOpened = &t
+	f.Projects[index].OpenCount++
+
+	err = f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Projects, nil
+}
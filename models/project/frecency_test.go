@@ -0,0 +1,115 @@
+package project
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Frecency(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	testRuns := []struct {
+		testName string
+		project  Project
+
+		expectedFrecency float64
+	}{
+		{
+			testName: "never opened",
+			project:  Project{Name: "example-project", Path: "./"},
+
+			expectedFrecency: 0,
+		},
+		{
+			testName: "opened within the hour",
+			project:  Project{Name: "example-project", Path: "./", LastOpened: ago(10 * time.Minute), OpenCount: 3},
+
+			expectedFrecency: 12,
+		},
+		{
+			testName: "opened within the day",
+			project:  Project{Name: "example-project", Path: "./", LastOpened: ago(5 * time.Hour), OpenCount: 3},
+
+			expectedFrecency: 6,
+		},
+		{
+			testName: "opened within the week",
+			project:  Project{Name: "example-project", Path: "./", LastOpened: ago(72 * time.Hour), OpenCount: 3},
+
+			expectedFrecency: 1.5,
+		},
+		{
+			testName: "opened a long time ago",
+			project:  Project{Name: "example-project", Path: "./", LastOpened: ago(90 * 24 * time.Hour), OpenCount: 40},
+
+			expectedFrecency: 10,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedFrecency, testRun.project.Frecency(now))
+		})
+	}
+}
+
+func Test_SortByFrecency(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	hourAgo := now.Add(-30 * time.Minute)
+	monthAgo := now.Add(-30 * 24 * time.Hour)
+
+	projects := []Project{
+		{Name: "never-1", Path: "./"},
+		{Name: "old", Path: "./", LastOpened: &monthAgo, OpenCount: 2},
+		{Name: "never-2", Path: "./"},
+		{Name: "daily", Path: "./", LastOpened: &hourAgo, OpenCount: 2},
+	}
+
+	sorted := SortByFrecency(projects, now)
+
+	names := make([]string, len(sorted))
+	for i, p := range sorted {
+		names[i] = p.Name
+	}
+	assert.Equal(t, []string{"daily", "old", "never-1", "never-2"}, names)
+	assert.Equal(t, "never-1", projects[0].Name)
+}
+
+func Test_RecordOpen(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "example-project-1",
+			"path": "./"
+		},
+		{
+			"id": "2",
+			"name": "example-project-2",
+			"path": "./",
+			"openCount": 4
+		}
+	]
+	`)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	projects, err := RecordOpen("2", now)
+	assert.Nil(t, err)
+	assert.Equal(t, []Project{
+		{ID: "1", Name: "example-project-1", Path: "./"},
+		{ID: "2", Name: "example-project-2", Path: "./", LastOpened: &now, OpenCount: 5},
+	}, projects)
+
+	onDiskProjects, _ := GetAll()
+	assert.True(t, now.Equal(*onDiskProjects[1].LastOpened))
+	assert.Equal(t, 5, onDiskProjects[1].OpenCount)
+
+	_, err = RecordOpen("3", now)
+	assert.NotNil(t, err)
+}
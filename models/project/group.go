@@ -0,0 +1,132 @@
+package project
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GroupSeparator separates the names of nested groups, e.g. "Work/Client A".
+const GroupSeparator = "/"
+
+// A Group is a named folder of projects, nested groups being separated by GroupSeparator.
+// Projects reference their group by name, groups only store their state on-disk.
+type Group struct {
+	Name      string `json:"name"`
+	Collapsed bool   `json:"collapsed,omitempty"`
+}
+
+// Depth returns the number of groups from the root to the group, 1 for top-level groups.
+func (g Group) Depth() int {
+	return strings.Count(g.Name, GroupSeparator) + 1
+}
+
+// BaseName returns the group's name without the name of its parent groups.
+func (g Group) BaseName() string {
+	return g.Name[strings.LastIndex(g.Name, GroupSeparator)+1:]
+}
+
+// Contains returns true if the given group name is the group itself or one of its subgroups.
+func (g Group) Contains(name string) bool {
+	return name == g.Name || strings.HasPrefix(name, g.Name+GroupSeparator)
+}
+
+// NormalizeGroup trims the spaces around each group name and removes empty ones, e.g. " Work / Client A/" becomes "Work/Client A".
+func NormalizeGroup(name string) string {
+	var names []string
+	for _, n := range strings.Split(name, GroupSeparator) {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return strings.Join(names, GroupSeparator)
+}
+
+// GetGroups fetches the groups from the disk and returns them, every parent group being listed right before its subgroups.
+// If an error happens throughout the process, it returns the error as the second return value.
+func GetGroups() ([]Group, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+	return f.Groups, nil
+}
+
+// SetGroupCollapsed saves whether the group with the given name is collapsed.
+// If no error is encountered, it returns the newly updated groups list. Else it returns the error as the second return value.
+func SetGroupCollapsed(name string, collapsed bool) ([]Group, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(f.Groups, func(g Group) bool { return g.Name == name })
+	if index < 0 {
+		return nil, fmt.Errorf("group '%s' not found", name)
+	}
+	f.Groups[index].Collapsed = collapsed
+
+	err = f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Groups, nil
+}
+
+// MoveToGroup moves the project with the given ID into the group with the given name, an empty name removing it from its group.
+// If no error is encountered, it returns the newly updated projects list. Else it returns the error as the second return value.
+func MoveToGroup(id string, name string) ([]Project, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := f.indexOf(id)
+	if err != nil {
+		return nil, err
+	}
+	f.Projects[index].Group = NormalizeGroup(name)
+
+	err = f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Projects, nil
+}
+
+// syncGroups lists every group used by the projects and their parent groups, keeping the state of the known groups.
+// Groups without any project are removed.
+func (f *projectsFile) syncGroups() {
+	known := map[string]Group{}
+	for _, g := range f.Groups {
+		known[g.Name] = g
+	}
+
+	var groups []Group
+	for _, p := range f.Projects {
+		if p.Group == "" {
+			continue
+		}
+
+		names := strings.Split(p.Group, GroupSeparator)
+		for i := range names {
+			name := strings.Join(names[:i+1], GroupSeparator)
+			if slices.ContainsFunc(groups, func(g Group) bool { return g.Name == name }) {
+				continue
+			}
+
+			g, ok := known[name]
+			if !ok {
+				g = Group{Name: name}
+			}
+			groups = append(groups, g)
+		}
+	}
+
+	slices.SortFunc(groups, func(a, b Group) int {
+		return slices.Compare(strings.Split(a.Name, GroupSeparator), strings.Split(b.Name, GroupSeparator))
+	})
+	f.Groups = groups
+}
@@ -0,0 +1,222 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetGroups(t *testing.T) {
+	testRuns := []struct {
+		testName        string
+		initialDiskData string
+
+		expectedGroups []Group
+		expectErr      bool
+	}{
+		{
+			testName: "legacy list of projects without group",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./"
+				}
+			]
+			`,
+
+			expectedGroups: nil,
+			expectErr:      false,
+		},
+		{
+			testName: "nested groups are listed with their parents",
+			initialDiskData: `
+			{
+				"projects": [
+					{
+						"name": "api",
+						"path": "./",
+						"group": "Work/Client A"
+					},
+					{
+						"name": "blog",
+						"path": "./",
+						"group": "Personal"
+					},
+					{
+						"name": "tools",
+						"path": "./",
+						"group": "Work-Archive"
+					}
+				],
+				"groups": [
+					{
+						"name": "Work",
+						"collapsed": true
+					},
+					{
+						"name": "Unused"
+					}
+				]
+			}
+			`,
+
+			expectedGroups: []Group{
+				{Name: "Personal"},
+				{Name: "Work", Collapsed: true},
+				{Name: "Work/Client A"},
+				{Name: "Work-Archive"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "invalid group name",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./",
+					"group": "Work//Client A"
+				}
+			]
+			`,
+
+			expectedGroups: nil,
+			expectErr:      true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(testRun.initialDiskData)
+
+			g, err := GetGroups()
+
+			assert.Equal(t, testRun.expectedGroups, g)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_SetGroupCollapsed(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"name": "api",
+			"path": "./",
+			"group": "Work/Client A"
+		}
+	]
+	`)
+
+	groups, err := SetGroupCollapsed("Work", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []Group{{Name: "Work", Collapsed: true}, {Name: "Work/Client A"}}, groups)
+
+	onDiskGroups, _ := GetGroups()
+	assert.Equal(t, groups, onDiskGroups)
+
+	_, err = SetGroupCollapsed("Personal", true)
+	assert.NotNil(t, err)
+}
+
+func Test_MoveToGroup(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		id       string
+		group    string
+
+		expectedProjects []Project
+		expectedGroups   []Group
+		expectErr        bool
+	}{
+		{
+			testName: "move into new nested group",
+			id:       "1",
+			group:    " Personal / Side projects ",
+
+			expectedProjects: []Project{
+				{ID: "1", Name: "api", Path: "./", Group: "Personal/Side projects"},
+				{ID: "2", Name: "web", Path: "./"},
+			},
+			expectedGroups: []Group{{Name: "Personal"}, {Name: "Personal/Side projects"}},
+			expectErr:      false,
+		},
+		{
+			testName: "move out of group",
+			id:       "1",
+			group:    "",
+
+			expectedProjects: []Project{
+				{ID: "1", Name: "api", Path: "./"},
+				{ID: "2", Name: "web", Path: "./"},
+			},
+			expectedGroups: nil,
+			expectErr:      false,
+		},
+		{
+			testName: "unknown project",
+			id:       "3",
+			group:    "Work",
+
+			expectedProjects: nil,
+			expectedGroups:   []Group{{Name: "Work"}},
+			expectErr:        true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(`
+			[
+				{
+					"id": "1",
+					"name": "api",
+					"path": "./",
+					"group": "Work"
+				},
+				{
+					"id": "2",
+					"name": "web",
+					"path": "./"
+				}
+			]
+			`)
+
+			projects, err := MoveToGroup(testRun.id, testRun.group)
+
+			assert.Equal(t, testRun.expectedProjects, projects)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			onDiskGroups, _ := GetGroups()
+			assert.Equal(t, testRun.expectedGroups, onDiskGroups)
+		})
+	}
+}
+
+func Test_NormalizeGroup(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		name     string
+
+		expectedName string
+	}{
+		{testName: "empty name", name: "  ", expectedName: ""},
+		{testName: "top-level group", name: "Personal", expectedName: "Personal"},
+		{testName: "nested group with spaces", name: " Work / Client A/", expectedName: "Work/Client A"},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedName, NormalizeGroup(testRun.name))
+		})
+	}
+}
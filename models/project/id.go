@@ -0,0 +1,59 @@
+package project
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// newID returns a random ID which isn't used by any project of the file.
+func (f projectsFile) newID() string {
+	for {
+		b := make([]byte, 8)
+		rand.Read(b)
+
+		id := hex.EncodeToString(b)
+		if _, err := f.indexOf(id); err != nil {
+			return id
+		}
+	}
+}
+
+// indexOf returns the index of the project with the given ID, or an error if not found.
+func (f projectsFile) indexOf(id string) (int, error) {
+	for i, p := range f.Projects {
+		if p.ID == id {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("project with id '%s' not found", id)
+}
+
+// migrateIDs generates the IDs of the projects saved without one and replaces the set members referencing a project by name with its ID.
+// Returns true if the file was changed.
+func (f *projectsFile) migrateIDs() bool {
+	changed := false
+	for i := range f.Projects {
+		if f.Projects[i].ID == "" {
+			f.Projects[i].ID = f.newID()
+			changed = true
+		}
+	}
+
+	for i := range f.Sets {
+		for j, member := range f.Sets[i].Members {
+			if _, err := f.indexOf(member); err == nil {
+				continue
+			}
+
+			for _, p := range f.Projects {
+				if p.Name == member {
+					f.Sets[i].Members[j] = p.ID
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return changed
+}
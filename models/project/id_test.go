@@ -0,0 +1,212 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GeneratedIDs(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"name": "example-project-1",
+			"path": "./"
+		},
+		{
+			"id": "2",
+			"name": "example-project-2",
+			"path": "./"
+		}
+	]
+	`)
+
+	projects, err := GetAll()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, projects[0].ID)
+	assert.NotEqual(t, "2", projects[0].ID)
+	assert.Equal(t, "2", projects[1].ID)
+
+	reloadedProjects, _ := GetAll()
+	assert.Equal(t, projects, reloadedProjects)
+}
+
+func Test_DuplicateIDs(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "example-project-1",
+			"path": "./"
+		},
+		{
+			"id": "1",
+			"name": "example-project-2",
+			"path": "./"
+		}
+	]
+	`)
+
+	projects, err := GetAll()
+	assert.Nil(t, projects)
+	assert.NotNil(t, err)
+}
+
+func Test_MutationsByID(t *testing.T) {
+	initialDiskData := `
+	{
+		"projects": [
+			{
+				"id": "1",
+				"name": "example-project-1",
+				"path": "./",
+				"group": "Work"
+			},
+			{
+				"id": "2",
+				"name": "example-project-2",
+				"path": "./"
+			}
+		],
+		"sets": [
+			{
+				"name": "stack",
+				"members": ["1", "2"]
+			}
+		]
+	}
+	`
+
+	testRuns := []struct {
+		testName string
+		mutate   func() ([]Project, error)
+
+		expectedProjects []Project
+		expectedSets     []Set
+		expectErr        bool
+	}{
+		{
+			testName: "save after project",
+			mutate: func() ([]Project, error) {
+				return SaveAfter("1", Project{ID: "3", Name: "example-project-3", Path: "./tests"})
+			},
+
+			expectedProjects: []Project{
+				{ID: "1", Name: "example-project-1", Path: "./", Group: "Work"},
+				{ID: "3", Name: "example-project-3", Path: "./tests"},
+				{ID: "2", Name: "example-project-2", Path: "./"},
+			},
+			expectedSets: []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:    false,
+		},
+		{
+			testName: "save at the end",
+			mutate: func() ([]Project, error) {
+				return SaveAfter("", Project{ID: "3", Name: "example-project-3", Path: "./tests"})
+			},
+
+			expectedProjects: []Project{
+				{ID: "1", Name: "example-project-1", Path: "./", Group: "Work"},
+				{ID: "2", Name: "example-project-2", Path: "./"},
+				{ID: "3", Name: "example-project-3", Path: "./tests"},
+			},
+			expectedSets: []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:    false,
+		},
+		{
+			testName: "save with existing ID",
+			mutate: func() ([]Project, error) {
+				return SaveAfter("", Project{ID: "2", Name: "example-project-3", Path: "./"})
+			},
+
+			expectedProjects: nil,
+			expectedSets:     []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:        true,
+		},
+		{
+			testName: "save after unknown project",
+			mutate:   func() ([]Project, error) { return SaveAfter("3", Project{Name: "example-project-3", Path: "./"}) },
+
+			expectedProjects: nil,
+			expectedSets:     []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:        true,
+		},
+		{
+			testName: "update keeps the ID",
+			mutate:   func() ([]Project, error) { return UpdateByID("2", Project{ID: "4", Name: "renamed", Path: "./"}) },
+
+			expectedProjects: []Project{
+				{ID: "1", Name: "example-project-1", Path: "./", Group: "Work"},
+				{ID: "2", Name: "renamed", Path: "./"},
+			},
+			expectedSets: []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:    false,
+		},
+		{
+			testName: "update unknown project",
+			mutate:   func() ([]Project, error) { return UpdateByID("3", Project{Name: "renamed", Path: "./"}) },
+
+			expectedProjects: nil,
+			expectedSets:     []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:        true,
+		},
+		{
+			testName: "delete removes the set member",
+			mutate:   func() ([]Project, error) { return DeleteByID("1") },
+
+			expectedProjects: []Project{
+				{ID: "2", Name: "example-project-2", Path: "./"},
+			},
+			expectedSets: []Set{{Name: "stack", Members: []string{"2"}}},
+			expectErr:    false,
+		},
+		{
+			testName: "delete unknown project",
+			mutate:   func() ([]Project, error) { return DeleteByID("3") },
+
+			expectedProjects: nil,
+			expectedSets:     []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:        true,
+		},
+		{
+			testName: "swap exchanges positions and groups",
+			mutate:   func() ([]Project, error) { return SwapByID("2", "1") },
+
+			expectedProjects: []Project{
+				{ID: "2", Name: "example-project-2", Path: "./", Group: "Work"},
+				{ID: "1", Name: "example-project-1", Path: "./"},
+			},
+			expectedSets: []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:    false,
+		},
+		{
+			testName: "swap with unknown project",
+			mutate:   func() ([]Project, error) { return SwapByID("1", "3") },
+
+			expectedProjects: nil,
+			expectedSets:     []Set{{Name: "stack", Members: []string{"1", "2"}}},
+			expectErr:        true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(initialDiskData)
+
+			p, err := testRun.mutate()
+
+			assert.Equal(t, testRun.expectedProjects, p)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+
+				onDiskProjects, _ := GetAll()
+				assert.Equal(t, testRun.expectedProjects, onDiskProjects)
+			}
+
+			s, _ := GetSets()
+			assert.Equal(t, testRun.expectedSets, s)
+		})
+	}
+}
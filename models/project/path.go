@@ -0,0 +1,104 @@
+package project
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/marcantoineg/fileutil"
+)
+
+// DuplicateError is returned when a project has the same name or path as another project of the file.
+type DuplicateError struct {
+	// field shared with the other project, either "name" or "path"
+	Field string
+
+	// the other project
+	Project Project
+}
+
+func (e *DuplicateError) Error() string {
+	if e.Field == "name" {
+		return fmt.Sprintf("a project named '%s' already exists", e.Project.Name)
+	}
+	return fmt.Sprintf("project '%s' already has this path", e.Project.Name)
+}
+
+// CanonicalPath returns the project's path made absolute, with the tilde expanded, cleaned and its symlinks resolved,
+// so different spellings of the same location are equal. Paths of remote projects are only cleaned.
+// The project's Path is kept as entered by the user.
+func (p Project) CanonicalPath() string {
+	if p.IsRemote() {
+		return path.Clean(p.Path)
+	}
+
+	abs, err := filepath.Abs(fileutil.ReplaceTilde(p.Path))
+	if err != nil {
+		return filepath.Clean(p.Path)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	return abs
+}
+
+// normalizePath returns the project's path cleaned, e.g. without trailing or repeated separators, keeping the form entered by the user:
+// a leading "~" isn't expanded and relative paths starting with "./" keep it.
+func (p Project) normalizePath() string {
+	if p.Path == "" {
+		return p.Path
+	}
+	if p.IsRemote() {
+		return path.Clean(p.Path)
+	}
+
+	cleaned := filepath.Clean(p.Path)
+	if !strings.HasPrefix(p.Path, "./") || strings.HasPrefix(cleaned, "..") {
+		return cleaned
+	}
+	if cleaned == "." {
+		return "./"
+	}
+	return "./" + cleaned
+}
+
+// SamePath returns true if both projects point to the same location on the same host.
+func (p Project) SamePath(other Project) bool {
+	return p.Host == other.Host && p.CanonicalPath() == other.CanonicalPath()
+}
+
+// CheckDuplicates fetches the projects from the disk and returns a *DuplicateError if another project has the same name or path as the given one,
+// the name being checked first. The project with the same ID is ignored so a project can be checked against the others when edited.
+func CheckDuplicates(p Project) error {
+	f, err := readProjectsFile()
+	if err != nil {
+		return err
+	}
+
+	if err := f.checkName(p); err != nil {
+		return err
+	}
+	return f.checkPath(p)
+}
+
+// checkPath returns a *DuplicateError if another project of the file than the one with the same ID has the project's path.
+func (f projectsFile) checkPath(p Project) error {
+	for _, other := range f.Projects {
+		if other.ID != p.ID && other.SamePath(p) {
+			return &DuplicateError{Field: "path", Project: other}
+		}
+	}
+	return nil
+}
+
+// checkName returns a *DuplicateError if another project of the file than the one with the same ID has the project's name.
+func (f projectsFile) checkName(p Project) error {
+	for _, other := range f.Projects {
+		if other.ID != p.ID && other.Name == p.Name {
+			return &DuplicateError{Field: "name", Project: other}
+		}
+	}
+	return nil
+}
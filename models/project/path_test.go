@@ -0,0 +1,124 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcantoineg/fileutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CanonicalPath(t *testing.T) {
+	dir := t.TempDir()
+	os.Mkdir(filepath.Join(dir, "api"), os.ModePerm)
+	os.Symlink(filepath.Join(dir, "api"), filepath.Join(dir, "api-link"))
+	resolvedDir, _ := filepath.EvalSymlinks(dir)
+	wd, _ := os.Getwd()
+
+	testRuns := []struct {
+		testName string
+		project  Project
+
+		expectedPath string
+	}{
+		{
+			testName: "trailing slash",
+			project:  Project{Path: filepath.Join(dir, "api") + "/"},
+
+			expectedPath: filepath.Join(resolvedDir, "api"),
+		},
+		{
+			testName: "symlink",
+			project:  Project{Path: filepath.Join(dir, "api-link")},
+
+			expectedPath: filepath.Join(resolvedDir, "api"),
+		},
+		{
+			testName: "relative path",
+			project:  Project{Path: "./tests/../"},
+
+			expectedPath: wd,
+		},
+		{
+			testName: "tilde",
+			project:  Project{Path: "~/not-a-valid-path/"},
+
+			expectedPath: fileutil.ReplaceTilde("~/not-a-valid-path"),
+		},
+		{
+			testName: "remote path",
+			project:  Project{Path: "~/code/api/", Host: "dev-box"},
+
+			expectedPath: "~/code/api",
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedPath, testRun.project.CanonicalPath())
+		})
+	}
+}
+
+func Test_CheckDuplicates(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"id": "1",
+			"name": "api",
+			"path": "./"
+		},
+		{
+			"id": "2",
+			"name": "web",
+			"path": "~/code/web",
+			"host": "dev-box"
+		}
+	]
+	`)
+
+	testRuns := []struct {
+		testName string
+		project  Project
+
+		expectedErr error
+	}{
+		{
+			testName: "no duplicate",
+			project:  Project{Name: "docs", Path: "./tests"},
+
+			expectedErr: nil,
+		},
+		{
+			testName: "same name",
+			project:  Project{Name: "api", Path: "./tests"},
+
+			expectedErr: &DuplicateError{Field: "name", Project: Project{ID: "1", Name: "api", Path: "./"}},
+		},
+		{
+			testName: "same path spelled differently",
+			project:  Project{Name: "docs", Path: "./tests/.."},
+
+			expectedErr: &DuplicateError{Field: "path", Project: Project{ID: "1", Name: "api", Path: "./"}},
+		},
+		{
+			testName: "same path on another host",
+			project:  Project{Name: "docs", Path: "~/code/web/"},
+
+			expectedErr: nil,
+		},
+		{
+			testName: "edited project is ignored",
+			project:  Project{ID: "1", Name: "api", Path: "."},
+
+			expectedErr: nil,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedErr, CheckDuplicates(testRun.project))
+		})
+	}
+}
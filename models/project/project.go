@@ -3,7 +3,8 @@ package project
 
 import (
 	"errors"
-	"fmt"
+	"strings"
+	"time"
 
 	"ls-projects/models/config"
 
@@ -13,153 +14,237 @@ import (
 // A Project stores simple information about a project on disk.
 // It is a representation of the data on-disk and in-memory.
 type Project struct {
+	// unique identifier, generated when the project is first saved or loaded
+	ID string `json:"id"`
+
 	Name string `json:"name"`
 	Path string `json:"path"`
+
+	// optional launcher taking precedence over the one defined in the config
+	Launcher *config.Launcher `json:"launcher,omitempty"`
+
+	// additional folders opened along the path in a multi-root workspace
+	Folders []string `json:"folders,omitempty"`
+
+	// optional SSH host on which the path is located
+	Host string `json:"host,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
+
+	// name of the group listing the project, nested groups being separated by slashes
+	Group string `json:"group,omitempty"`
+
+	// whether the project is listed before every other project
+	Pinned bool `json:"pinned,omitempty"`
+
+	// free-text notes about the project, may span multiple lines
+	Description string `json:"description,omitempty"`
+
+	// last time the project was opened, nil if it was never opened
+	LastOpened *time.Time `json:"lastOpened,omitempty"`
+
+	// number of times the project was opened
+	OpenCount int `json:"openCount,omitempty"`
+
+	// commands run around the project's launch, after the global ones
+	Hooks *config.Hooks `json:"hooks,omitempty"`
+
+	// named shell commands runnable from the projects list
+	Commands []Command `json:"commands,omitempty"`
+
+	// environment variables set when launching the project, their values can reference other variables, e.g. "$HOME/.kube/config"
+	Env map[string]string `json:"env,omitempty"`
+
+	// whether the path or an additional folder is missing on the host, set when loading the project
+	Missing bool `json:"-"`
+
+	// whether the project can be saved with the path of another project, reset once saved
+	AllowDuplicatePath bool `json:"-"`
 }
 
+// WorkspaceExtension is the extension of VS Code's workspace files.
+const WorkspaceExtension = ".code-workspace"
+
 // implements interface list.Item for type Project
 func (p Project) FilterValue() string {
 	return p.Name
 }
 
-// ValidatePath returns a boolean value equal to wether or not the path exists on the host.
+// ValidatePath returns a boolean value equal to wether or not the path, which can be a workspace file, and every additional folder exist on the host.
+// Remote projects are not checked since their path is not on the host.
 func (p Project) ValidatePath() bool {
-	return fileutil.Exists(p.Path)
-}
-
-// GetAll fetches the projects from the disk and returns them.
-// If an error happens throughout the process, it returns the error as the second return value.
-func GetAll() ([]Project, error) {
-	if exists := fileutil.Exists(getProjectsFilePath()); !exists {
-		err := fileutil.CreateEmptyListFile(getProjectsFilePath())
-		if err != nil {
-			return nil, err
-		}
+	if p.IsRemote() {
+		return true
 	}
 
-	var projects []Project
-	err := fileutil.ReadFromFile(&projects, getProjectsFilePath())
-	if err != nil {
-		return nil, err
+	if !fileutil.Exists(p.Path) {
+		return false
 	}
 
-	for i := range projects {
-		var project = projects[i]
-		if project.Name == "" || project.Path == "" {
-			return nil, errors.New("both Name and Path fields are required")
+	for _, folder := range p.Folders {
+		if !fileutil.Exists(folder) {
+			return false
 		}
+	}
+	return true
+}
 
-		exists := fileutil.Exists(projects[i].Path)
-		if !exists {
-			return nil, fmt.Errorf("directory/file %s does not exists", projects[i].Path)
-		}
+// IsRemote returns true if the project is located on a remote host.
+func (p Project) IsRemote() bool {
+	return p.Host != ""
+}
+
+// IsWorkspaceFile returns true if the project's path points at a VS Code workspace file.
+func (p Project) IsWorkspaceFile() bool {
+	return strings.HasSuffix(p.Path, WorkspaceExtension)
+}
+
+// GetAll fetches the projects from the disk and returns them, projects whose path is missing being flagged instead of failing the loading.
+// If an error happens throughout the process, it returns the error as the second return value.
+func GetAll() ([]Project, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
 	}
-	return projects, err
+	return f.Projects, nil
 }
 
 // Save fetches the projects from the disk, appends the project given as the parameter at the given index, then saves the new projects on the disk.
 // If no error is encountered, it returns the newly updated projects list. Else it returns the error as the second return value.
 func Save(index int, project Project) ([]Project, error) {
-	onDiskProjects, err := GetAll()
+	f, err := readProjectsFile()
 	if err != nil {
 		return nil, err
 	}
 
-	if index < 0 || (index >= len(onDiskProjects) && len(onDiskProjects) != 0) {
+	if index < 0 || (index >= len(f.Projects) && len(f.Projects) != 0) {
 		return nil, errors.New("index out of bound")
 	}
 
-	var projects []Project
-	if len(onDiskProjects) == 0 {
-		projects = []Project{project}
-	} else {
-		projects = append([]Project{}, onDiskProjects[:index+1]...)
-		projects = append(projects, project)
-		projects = append(projects, onDiskProjects[index+1:]...)
-	}
+	return f.insertAfter(index, project)
+}
 
-	err = fileutil.SaveToFile(projects, getProjectsFilePath())
+// SaveAfter fetches the projects from the disk, appends the project given as the parameter after the project with the given ID, then saves the new projects on the disk.
+// If the ID is empty, the project is appended at the end of the list.
+// If no error is encountered, it returns the newly updated projects list. Else it returns the error as the second return value.
+func SaveAfter(id string, project Project) ([]Project, error) {
+	f, err := readProjectsFile()
 	if err != nil {
 		return nil, err
 	}
 
-	return projects, nil
+	index := len(f.Projects) - 1
+	if id != "" {
+		if index, err = f.indexOf(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return f.insertAfter(index, project)
 }
 
 // Update edit the project list on-disk.
 // If the index is not found, an error is returned as the second parameter
 func Update(index int, project Project) ([]Project, error) {
-	projects, err := GetAll()
+	f, err := readProjectsFile()
 	if err != nil {
 		return nil, err
 	}
 
-	if index < 0 || index >= len(projects) {
+	if index < 0 || index >= len(f.Projects) {
 		return nil, errors.New("index out of bound")
 	}
 
-	projects[index] = project
+	return f.update(index, project)
+}
+
+// UpdateByID replaces the project with the given ID on-disk, the project keeping its ID.
+// If the ID is not found, an error is returned as the second parameter
+func UpdateByID(id string, project Project) ([]Project, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
 
-	err = fileutil.SaveToFile(projects, getProjectsFilePath())
+	index, err := f.indexOf(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return projects, nil
+	return f.update(index, project)
 }
 
 // Delete fetches the projects from the disk by index, checks it's the same as the in-memory project, then deletes it from the disk.
 // If no error is encountered, it returns the newly updated projects list. Else it returns the error as the second return value.
 func Delete(index int, project Project) ([]Project, error) {
-	projects, err := GetAll()
+	f, err := readProjectsFile()
 	if err != nil {
 		return nil, err
 	}
 
-	if index < 0 || index >= len(projects) {
+	if index < 0 || index >= len(f.Projects) {
 		return nil, errors.New("project not found")
 	}
 
-	onDiskProject := projects[index]
+	onDiskProject := f.Projects[index]
 	if onDiskProject.Name != project.Name || onDiskProject.Path != project.Path {
 		return nil, errors.New("project on disk did not match project in memory")
 	}
 
-	projects = append(projects[:index], projects[index+1:]...)
+	return f.delete(index)
+}
+
+// DeleteByID fetches the projects from the disk, then deletes the project with the given ID from the disk.
+// If no error is encountered, it returns the newly updated projects list. Else it returns the error as the second return value.
+func DeleteByID(id string) ([]Project, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
 
-	err = fileutil.SaveToFile(projects, getProjectsFilePath())
+	index, err := f.indexOf(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return projects, nil
+	return f.delete(index)
 }
 
-// SwapIndex fetches the projects from the disk, swap both projects by index then saves the updated list.
+// SwapIndex fetches the projects from the disk, swap both projects by index and group then saves the updated list.
 // Returns the updated list if no error occurs. Forwards the error otherwise.
 func SwapIndex(initialIndex int, targetIndex int) ([]Project, error) {
-	projects, err := GetAll()
+	f, err := readProjectsFile()
 	if err != nil {
 		return nil, err
 	}
 
-	if initialIndex < 0 || initialIndex >= len(projects) {
+	if initialIndex < 0 || initialIndex >= len(f.Projects) {
 		return nil, errors.New("initial index out of bound")
-	} else if targetIndex < 0 || targetIndex >= len(projects) {
+	} else if targetIndex < 0 || targetIndex >= len(f.Projects) {
 		return nil, errors.New("target index out of bound")
 	}
 
-	if initialIndex == targetIndex {
-		return projects, nil
-	}
+	return f.swap(initialIndex, targetIndex)
+}
 
-	p := projects[initialIndex]
-	projects[initialIndex] = projects[targetIndex]
-	projects[targetIndex] = p
+// SwapByID fetches the projects from the disk, swap both projects with the given IDs by position and group then saves the updated list.
+// Returns the updated list if no error occurs. Forwards the error otherwise.
+func SwapByID(initialID string, targetID string) ([]Project, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
 
-	err = fileutil.SaveToFile(projects, getProjectsFilePath())
+	initialIndex, err := f.indexOf(initialID)
+	if err != nil {
+		return nil, err
+	}
+	targetIndex, err := f.indexOf(targetID)
+	if err != nil {
+		return nil, err
+	}
 
-	return projects, err
+	return f.swap(initialIndex, targetIndex)
 }
 
 // getProjectsFilePath fetches the projects file path from the app's config.
@@ -75,7 +75,95 @@ func Test_GetProjects(t *testing.T) {
 			expectErr: false,
 		},
 		{
-			testName: "single project with invalid path",
+			testName: "single project with launcher override",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./",
+					"launcher": {
+						"command": "nvim {path}",
+						"runInProjectDir": true
+					}
+				}
+			]
+			`,
+
+			expectedData: []Project{
+				{Name: "example-project", Path: "./", Launcher: &config.Launcher{Command: "nvim {path}", RunInProjectDir: true}},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "remote project with path missing on the host",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "/srv/not-a-valid-path",
+					"host": "devbox"
+				}
+			]
+			`,
+
+			expectedData: []Project{
+				{Name: "example-project", Path: "/srv/not-a-valid-path", Host: "devbox"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "single project with multi-line description",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./",
+					"description": "legacy billing API\ndo not touch on Fridays"
+				}
+			]
+			`,
+
+			expectedData: []Project{
+				{Name: "example-project", Path: "./", Description: "legacy billing API\ndo not touch on Fridays"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "pinned project",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./",
+					"pinned": true
+				}
+			]
+			`,
+
+			expectedData: []Project{
+				{Name: "example-project", Path: "./", Pinned: true},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "single project with missing additional folder",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./",
+					"folders": ["not-a-valid-path"]
+				}
+			]
+			`,
+
+			expectedData: []Project{
+				{Name: "example-project", Path: "./", Folders: []string{"not-a-valid-path"}, Missing: true},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "single project with missing path",
 			initialDiskData: `
 			[
 				{
@@ -85,8 +173,10 @@ func Test_GetProjects(t *testing.T) {
 			]
 			`,
 
-			expectedData: nil,
-			expectErr:    true,
+			expectedData: []Project{
+				{Name: "example-project", Path: "not-a-valid-path", Missing: true},
+			},
+			expectErr: false,
 		},
 		{
 			testName: "single project with valid path including '~'",
@@ -138,7 +228,7 @@ func Test_GetProjects(t *testing.T) {
 
 			p, err := GetAll()
 
-			assert.Equal(t, testRun.expectedData, p)
+			assert.Equal(t, testRun.expectedData, withoutIDs(p))
 			if testRun.expectErr {
 				assert.NotNil(t, err)
 			} else {
@@ -148,6 +238,61 @@ func Test_GetProjects(t *testing.T) {
 	}
 }
 
+func Test_ValidatePath(t *testing.T) {
+	workspaceFile := t.TempDir() + "/example" + WorkspaceExtension
+	os.WriteFile(workspaceFile, []byte("{}"), os.ModePerm)
+
+	testRuns := []struct {
+		testName string
+		project  Project
+
+		expectedValid bool
+	}{
+		{
+			testName: "existing directory",
+			project:  Project{Name: "example-project", Path: "./"},
+
+			expectedValid: true,
+		},
+		{
+			testName: "missing directory",
+			project:  Project{Name: "example-project", Path: "not-a-valid-path"},
+
+			expectedValid: false,
+		},
+		{
+			testName: "existing workspace file",
+			project:  Project{Name: "example-project", Path: workspaceFile},
+
+			expectedValid: true,
+		},
+		{
+			testName: "existing additional folders",
+			project:  Project{Name: "example-project", Path: "./", Folders: []string{"~", "../"}},
+
+			expectedValid: true,
+		},
+		{
+			testName: "remote project",
+			project:  Project{Name: "example-project", Path: "not-a-valid-path", Host: "devbox"},
+
+			expectedValid: true,
+		},
+		{
+			testName: "missing additional folder",
+			project:  Project{Name: "example-project", Path: "./", Folders: []string{"~", "not-a-valid-path"}},
+
+			expectedValid: false,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			assert.Equal(t, testRun.expectedValid, testRun.project.ValidatePath())
+		})
+	}
+}
+
 func Test_SaveProject(t *testing.T) {
 	testRuns := []struct {
 		testName        string
@@ -179,12 +324,12 @@ func Test_SaveProject(t *testing.T) {
 				}
 			]
 			`,
-			project: Project{Name: "example-project-2", Path: "./"},
+			project: Project{Name: "example-project-2", Path: "./tests"},
 			index:   0,
 
 			expectedProjects: []Project{
 				{Name: "example-project-1", Path: "./"},
-				{Name: "example-project-2", Path: "./"},
+				{Name: "example-project-2", Path: "./tests"},
 			},
 			expectErr: false,
 		},
@@ -202,13 +347,13 @@ func Test_SaveProject(t *testing.T) {
 				}
 			]
 			`,
-			project: Project{Name: "example-project-3", Path: "./"},
+			project: Project{Name: "example-project-3", Path: "./tests"},
 			index:   1,
 
 			expectedProjects: []Project{
 				{Name: "example-project-1", Path: "./"},
 				{Name: "example-project-2", Path: "./"},
-				{Name: "example-project-3", Path: "./"},
+				{Name: "example-project-3", Path: "./tests"},
 			},
 			expectErr: false,
 		},
@@ -261,6 +406,68 @@ func Test_SaveProject(t *testing.T) {
 			expectedProjects: nil,
 			expectErr:        true,
 		},
+		{
+			testName: "save project with existing name",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./"
+				}
+			]
+			`,
+			project: Project{Name: "example-project-1", Path: "./tests"},
+			index:   0,
+
+			expectedProjects: nil,
+			expectErr:        true,
+		},
+		{
+			testName: "save project with existing path written differently",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./tests"
+				}
+			]
+			`,
+			project: Project{Name: "example-project-2", Path: "tests/../tests/"},
+			index:   0,
+
+			expectedProjects: nil,
+			expectErr:        true,
+		},
+		{
+			testName: "save project with existing path allowed",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./tests"
+				}
+			]
+			`,
+			project: Project{Name: "example-project-2", Path: "./tests", AllowDuplicatePath: true},
+			index:   0,
+
+			expectedProjects: []Project{
+				{Name: "example-project-1", Path: "./tests"},
+				{Name: "example-project-2", Path: "./tests"},
+			},
+			expectErr: false,
+		},
+		{
+			testName:        "save project with path to normalize",
+			initialDiskData: "[]",
+			project:         Project{Name: "example-project-1", Path: ".//tests/"},
+			index:           0,
+
+			expectedProjects: []Project{
+				{Name: "example-project-1", Path: "./tests"},
+			},
+			expectErr: false,
+		},
 		{
 			testName:        "save into invalid list",
 			initialDiskData: "[{}]",
@@ -278,7 +485,7 @@ func Test_SaveProject(t *testing.T) {
 
 			p, err := Save(testRun.index, testRun.project)
 
-			assert.Equal(t, testRun.expectedProjects, p)
+			assert.Equal(t, testRun.expectedProjects, withoutIDs(p))
 			if testRun.expectErr {
 				assert.NotNil(t, err)
 			} else {
@@ -339,6 +546,87 @@ func Test_UpdateProject(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			testName: "update with the name of another project",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./"
+				},
+				{
+					"name": "example-project-2",
+					"path": "./"
+				}
+			]
+			`,
+			index:   1,
+			project: Project{Name: "example-project-1", Path: "./"},
+
+			expectedProjects: nil,
+			expectErr:        true,
+		},
+		{
+			testName: "update with the path of another project",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./"
+				},
+				{
+					"name": "example-project-2",
+					"path": "./tests"
+				}
+			]
+			`,
+			index:   1,
+			project: Project{Name: "example-project-2", Path: "."},
+
+			expectedProjects: nil,
+			expectErr:        true,
+		},
+		{
+			testName: "update with the path of another project allowed",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./"
+				},
+				{
+					"name": "example-project-2",
+					"path": "./tests"
+				}
+			]
+			`,
+			index:   1,
+			project: Project{Name: "example-project-2", Path: "./", AllowDuplicatePath: true},
+
+			expectedProjects: []Project{
+				{Name: "example-project-1", Path: "./"},
+				{Name: "example-project-2", Path: "./"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "update with path to normalize",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./"
+				}
+			]
+			`,
+			index:   0,
+			project: Project{Name: "example-project-1", Path: "./tests/"},
+
+			expectedProjects: []Project{
+				{Name: "example-project-1", Path: "./tests"},
+			},
+			expectErr: false,
+		},
 		{
 			testName:        "out of bound from empty list on-disk",
 			initialDiskData: "[]",
@@ -392,7 +680,7 @@ func Test_UpdateProject(t *testing.T) {
 
 			p, err := Update(testRun.index, testRun.project)
 
-			assert.Equal(t, testRun.expectedProjects, p)
+			assert.Equal(t, testRun.expectedProjects, withoutIDs(p))
 			if testRun.expectErr {
 				assert.NotNil(t, err)
 			} else {
@@ -525,7 +813,7 @@ func Test_DeleteProject(t *testing.T) {
 
 			p, err := Delete(testRun.index, testRun.project)
 
-			assert.Equal(t, testRun.expectedProjects, p)
+			assert.Equal(t, testRun.expectedProjects, withoutIDs(p))
 			if testRun.expectErr {
 				assert.NotNil(t, err)
 			} else {
@@ -614,6 +902,30 @@ func Test_SwapIndex(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			testName: "swap projects from different groups",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project-1",
+					"path": "./",
+					"group": "Work"
+				},
+				{
+					"name": "example-project-2",
+					"path": "./"
+				}
+			]
+			`,
+			initialIndex: 0,
+			targetIndex:  1,
+
+			expectedProjects: []Project{
+				{Name: "example-project-2", Path: "./", Group: "Work"},
+				{Name: "example-project-1", Path: "./"},
+			},
+			expectErr: false,
+		},
 		{
 			testName:        "swap project out of bound initial index - empty list",
 			initialDiskData: "[]",
@@ -697,7 +1009,7 @@ func Test_SwapIndex(t *testing.T) {
 			saveStringToFile(testRun.initialDiskData)
 
 			p, err := SwapIndex(testRun.initialIndex, testRun.targetIndex)
-			assert.Equal(t, testRun.expectedProjects, p)
+			assert.Equal(t, testRun.expectedProjects, withoutIDs(p))
 			if testRun.expectErr {
 				assert.NotNil(t, err)
 			} else {
@@ -707,6 +1019,20 @@ func Test_SwapIndex(t *testing.T) {
 	}
 }
 
+// withoutIDs returns a copy of the projects without their IDs, generated randomly for the projects saved without one.
+func withoutIDs(projects []Project) []Project {
+	if projects == nil {
+		return nil
+	}
+
+	copied := make([]Project, len(projects))
+	for i, p := range projects {
+		p.ID = ""
+		copied[i] = p
+	}
+	return copied
+}
+
 func saveStringToFile(data string) error {
 	return os.WriteFile(getProjectsFilePath(), []byte(data), os.ModePerm)
 }
@@ -0,0 +1,193 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/marcantoineg/fileutil"
+)
+
+// projectsFile is the on-disk representation of the projects file.
+// Files containing only a list of projects are still supported and are migrated to this format on the next save,
+// their projects being outside of any group.
+type projectsFile struct {
+	Projects []Project `json:"projects"`
+	Sets     []Set     `json:"sets,omitempty"`
+	Groups   []Group   `json:"groups,omitempty"`
+}
+
+// readProjectsFile reads and validates the projects file, creating it if it doesn't exist.
+// If an error happens throughout the process, it returns the error as the second return value,
+// a *ValidationError listing every problem found if the file is invalid.
+func readProjectsFile() (projectsFile, error) {
+	f, err := decodeProjectsFile()
+	if err != nil {
+		return f, err
+	}
+
+	problems := f.validate()
+	for _, p := range problems {
+		if !p.Warning {
+			return f, &ValidationError{Path: getProjectsFilePath(), Problems: problems}
+		}
+	}
+
+	for i := range f.Projects {
+		f.Projects[i].Missing = !f.Projects[i].ValidatePath()
+	}
+
+	f.syncGroups()
+
+	// IDs must be saved right away to stay the same across reads
+	if f.migrateIDs() {
+		if err = f.save(); err != nil {
+			return f, err
+		}
+	}
+
+	return f, nil
+}
+
+// decodeProjectsFile reads the projects file without validating it, creating it if it doesn't exist.
+// If an error happens throughout the process, it returns the error as the second return value.
+func decodeProjectsFile() (projectsFile, error) {
+	var f projectsFile
+
+	if exists := fileutil.Exists(getProjectsFilePath()); !exists {
+		err := fileutil.CreateEmptyListFile(getProjectsFilePath())
+		if err != nil {
+			return f, err
+		}
+	}
+
+	var raw json.RawMessage
+	err := fileutil.ReadFromFile(&raw, getProjectsFilePath())
+	if err != nil {
+		return f, err
+	}
+
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(raw, &f.Projects)
+	} else {
+		err = json.Unmarshal(raw, &f)
+	}
+	if err != nil {
+		return f, fmt.Errorf("error decoding objects from file '%s'\n\n%s", getProjectsFilePath(), err)
+	}
+
+	if f.Projects == nil {
+		f.Projects = []Project{}
+	}
+
+	return f, nil
+}
+
+// save writes the projects file on the disk, updating the groups to the ones used by the projects.
+func (f *projectsFile) save() error {
+	f.syncGroups()
+	return fileutil.SaveToFile(f, getProjectsFilePath())
+}
+
+// insertAfter inserts the project after the given index, generating its ID if it has none and normalizing its path, then saves the file.
+// Returns a *DuplicateError if another project has the same name, or the same path unless the project allows it.
+func (f *projectsFile) insertAfter(index int, project Project) ([]Project, error) {
+	project.Path = project.normalizePath()
+	project.Missing = !project.ValidatePath()
+	if project.ID == "" {
+		project.ID = f.newID()
+	} else if _, err := f.indexOf(project.ID); err == nil {
+		return nil, fmt.Errorf("duplicate project id '%s'", project.ID)
+	}
+
+	if err := f.checkName(project); err != nil {
+		return nil, err
+	}
+	if !project.AllowDuplicatePath {
+		if err := f.checkPath(project); err != nil {
+			return nil, err
+		}
+	}
+	project.AllowDuplicatePath = false
+
+	if len(f.Projects) == 0 {
+		f.Projects = []Project{project}
+	} else {
+		projects := append([]Project{}, f.Projects[:index+1]...)
+		projects = append(projects, project)
+		f.Projects = append(projects, f.Projects[index+1:]...)
+	}
+
+	err := f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Projects, nil
+}
+
+// update replaces the project at the given index, keeping its ID and normalizing its path, then saves the file.
+// Returns a *DuplicateError if another project has the same name, or the same path unless the project allows it or its path didn't change.
+func (f *projectsFile) update(index int, project Project) ([]Project, error) {
+	project.ID = f.Projects[index].ID
+	project.Path = project.normalizePath()
+	if err := f.checkName(project); err != nil {
+		return nil, err
+	}
+	if !project.AllowDuplicatePath && !project.SamePath(f.Projects[index]) {
+		if err := f.checkPath(project); err != nil {
+			return nil, err
+		}
+	}
+	project.AllowDuplicatePath = false
+
+	project.Missing = !project.ValidatePath()
+	f.Projects[index] = project
+
+	err := f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Projects, nil
+}
+
+// delete removes the project at the given index from the projects and the sets, then saves the file.
+func (f *projectsFile) delete(index int) ([]Project, error) {
+	f.removeMember(f.Projects[index].ID)
+	f.Projects = append(f.Projects[:index], f.Projects[index+1:]...)
+
+	err := f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Projects, nil
+}
+
+// swap swaps the projects at the given indices, each project taking the group of the other one along its position, then saves the file.
+func (f *projectsFile) swap(initialIndex int, targetIndex int) ([]Project, error) {
+	if initialIndex == targetIndex {
+		return f.Projects, nil
+	}
+
+	projects := f.Projects
+	projects[initialIndex], projects[targetIndex] = projects[targetIndex], projects[initialIndex]
+	projects[initialIndex].Group, projects[targetIndex].Group = projects[targetIndex].Group, projects[initialIndex].Group
+
+	err := f.save()
+	return projects, err
+}
+
+// removeMember removes the project with the given ID from every set.
+func (f *projectsFile) removeMember(id string) {
+	for i := range f.Sets {
+		members := []string{}
+		for _, m := range f.Sets[i].Members {
+			if m != id {
+				members = append(members, m)
+			}
+		}
+		f.Sets[i].Members = members
+	}
+}
@@ -0,0 +1,137 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A Set is a named group of projects opened together.
+// Its members are referenced by their project's ID, members referenced by name in older files being migrated on load.
+type Set struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// implements interface list.Item for type Set
+func (s Set) FilterValue() string {
+	return s.Name
+}
+
+// Projects returns the set's member projects, in the set's order, found in the given projects.
+// Returns an error if a member doesn't match any project.
+func (s Set) Projects(projects []Project) ([]Project, error) {
+	members := make([]Project, len(s.Members))
+	for i, id := range s.Members {
+		found := false
+		for _, p := range projects {
+			if p.ID == id {
+				members[i] = p
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("set '%s' references unknown project '%s'", s.Name, id)
+		}
+	}
+	return members, nil
+}
+
+// GetSets fetches the sets from the disk and returns them.
+// If an error happens throughout the process, it returns the error as the second return value.
+func GetSets() ([]Set, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Sets == nil {
+		return []Set{}, nil
+	}
+	return f.Sets, nil
+}
+
+// SaveSet fetches the sets from the disk, appends the given set, then saves the new sets on the disk.
+// If no error is encountered, it returns the newly updated sets list. Else it returns the error as the second return value.
+func SaveSet(set Set) ([]Set, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if set.Name == "" {
+		return nil, errors.New("the set's name is required")
+	} else if f.setIndex(set.Name) >= 0 {
+		return nil, fmt.Errorf("a set named '%s' already exists", set.Name)
+	}
+
+	f.Sets = append(f.Sets, set)
+
+	err = f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Sets, nil
+}
+
+// UpdateSet replaces the set with the given name on-disk.
+// If the set is not found, an error is returned as the second parameter.
+func UpdateSet(name string, set Set) ([]Set, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	index := f.setIndex(name)
+	if index < 0 {
+		return nil, fmt.Errorf("set '%s' not found", name)
+	} else if set.Name == "" {
+		return nil, errors.New("the set's name is required")
+	} else if i := f.setIndex(set.Name); i >= 0 && i != index {
+		return nil, fmt.Errorf("a set named '%s' already exists", set.Name)
+	}
+
+	f.Sets[index] = set
+
+	err = f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Sets, nil
+}
+
+// DeleteSet deletes the set with the given name from the disk.
+// If no error is encountered, it returns the newly updated sets list. Else it returns the error as the second return value.
+func DeleteSet(name string) ([]Set, error) {
+	f, err := readProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	index := f.setIndex(name)
+	if index < 0 {
+		return nil, fmt.Errorf("set '%s' not found", name)
+	}
+
+	f.Sets = append(f.Sets[:index], f.Sets[index+1:]...)
+
+	err = f.save()
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Sets, nil
+}
+
+// setIndex returns the index of the set with the given name, -1 if not found.
+func (f projectsFile) setIndex(name string) int {
+	for i, s := range f.Sets {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,395 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetSets(t *testing.T) {
+	testRuns := []struct {
+		testName        string
+		initialDiskData string
+
+		expectedProjects []Project
+		expectedSets     []Set
+		expectErr        bool
+	}{
+		{
+			testName: "legacy list of projects",
+			initialDiskData: `
+			[
+				{
+					"name": "example-project",
+					"path": "./"
+				}
+			]
+			`,
+
+			expectedProjects: []Project{
+				{Name: "example-project", Path: "./"},
+			},
+			expectedSets: []Set{},
+			expectErr:    false,
+		},
+		{
+			testName: "projects and sets",
+			initialDiskData: `
+			{
+				"projects": [
+					{
+						"id": "1",
+						"name": "api",
+						"path": "./"
+					},
+					{
+						"id": "2",
+						"name": "web",
+						"path": "./"
+					}
+				],
+				"sets": [
+					{
+						"name": "payments stack",
+						"members": ["2", "1"]
+					}
+				]
+			}
+			`,
+
+			expectedProjects: []Project{
+				{Name: "api", Path: "./"},
+				{Name: "web", Path: "./"},
+			},
+			expectedSets: []Set{
+				{Name: "payments stack", Members: []string{"2", "1"}},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "sets referencing members by name are migrated to IDs",
+			initialDiskData: `
+			{
+				"projects": [
+					{
+						"id": "1",
+						"name": "api",
+						"path": "./"
+					},
+					{
+						"id": "2",
+						"name": "web",
+						"path": "./"
+					}
+				],
+				"sets": [
+					{
+						"name": "payments stack",
+						"members": ["web", "api", "infra"]
+					}
+				]
+			}
+			`,
+
+			expectedProjects: []Project{
+				{Name: "api", Path: "./"},
+				{Name: "web", Path: "./"},
+			},
+			expectedSets: []Set{
+				{Name: "payments stack", Members: []string{"2", "1", "infra"}},
+			},
+			expectErr: false,
+		},
+		{
+			testName:        "empty object",
+			initialDiskData: "{}",
+
+			expectedProjects: []Project{},
+			expectedSets:     []Set{},
+			expectErr:        false,
+		},
+		{
+			testName: "set without name",
+			initialDiskData: `
+			{
+				"projects": [],
+				"sets": [
+					{
+						"members": []
+					}
+				]
+			}
+			`,
+
+			expectedProjects: nil,
+			expectedSets:     nil,
+			expectErr:        true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(testRun.initialDiskData)
+
+			p, projectsErr := GetAll()
+			s, setsErr := GetSets()
+
+			assert.Equal(t, testRun.expectedProjects, withoutIDs(p))
+			assert.Equal(t, testRun.expectedSets, s)
+			if testRun.expectErr {
+				assert.NotNil(t, projectsErr)
+				assert.NotNil(t, setsErr)
+			} else {
+				assert.Nil(t, projectsErr)
+				assert.Nil(t, setsErr)
+			}
+		})
+	}
+}
+
+func Test_SaveSet(t *testing.T) {
+	testRuns := []struct {
+		testName        string
+		initialDiskData string
+		set             Set
+
+		expectedSets []Set
+		expectErr    bool
+	}{
+		{
+			testName: "save set into legacy list of projects",
+			initialDiskData: `
+			[
+				{
+					"id": "1",
+					"name": "api",
+					"path": "./"
+				}
+			]
+			`,
+			set: Set{Name: "stack", Members: []string{"1"}},
+
+			expectedSets: []Set{
+				{Name: "stack", Members: []string{"1"}},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "save set with existing name",
+			initialDiskData: `
+			{
+				"projects": [],
+				"sets": [
+					{
+						"name": "stack",
+						"members": []
+					}
+				]
+			}
+			`,
+			set: Set{Name: "stack", Members: []string{}},
+
+			expectedSets: nil,
+			expectErr:    true,
+		},
+		{
+			testName:        "save set without name",
+			initialDiskData: "[]",
+			set:             Set{Members: []string{}},
+
+			expectedSets: nil,
+			expectErr:    true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(testRun.initialDiskData)
+
+			s, err := SaveSet(testRun.set)
+
+			assert.Equal(t, testRun.expectedSets, s)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+
+				onDiskSets, _ := GetSets()
+				assert.Equal(t, testRun.expectedSets, onDiskSets)
+			}
+		})
+	}
+}
+
+func Test_SetsFollowProjectMutations(t *testing.T) {
+	initialDiskData := `
+	{
+		"projects": [
+			{
+				"id": "1",
+				"name": "api",
+				"path": "./"
+			},
+			{
+				"id": "2",
+				"name": "web",
+				"path": "./"
+			}
+		],
+		"sets": [
+			{
+				"name": "stack",
+				"members": ["1", "2"]
+			}
+		]
+	}
+	`
+
+	t.Run("renaming a project keeps the member", func(t *testing.T) {
+		saveStringToFile(initialDiskData)
+
+		_, err := Update(0, Project{Name: "backend", Path: "./"})
+		assert.Nil(t, err)
+
+		s, _ := GetSets()
+		assert.Equal(t, []Set{{Name: "stack", Members: []string{"1", "2"}}}, s)
+	})
+
+	t.Run("deleting a project removes the member", func(t *testing.T) {
+		saveStringToFile(initialDiskData)
+
+		_, err := Delete(1, Project{Name: "web", Path: "./"})
+		assert.Nil(t, err)
+
+		s, _ := GetSets()
+		assert.Equal(t, []Set{{Name: "stack", Members: []string{"1"}}}, s)
+	})
+}
+
+func Test_UpdateAndDeleteSet(t *testing.T) {
+	initialDiskData := `
+	{
+		"projects": [],
+		"sets": [
+			{
+				"name": "stack-1",
+				"members": []
+			},
+			{
+				"name": "stack-2",
+				"members": []
+			}
+		]
+	}
+	`
+
+	testRuns := []struct {
+		testName string
+		mutate   func() ([]Set, error)
+
+		expectedSets []Set
+		expectErr    bool
+	}{
+		{
+			testName: "rename set",
+			mutate:   func() ([]Set, error) { return UpdateSet("stack-1", Set{Name: "stack-3", Members: []string{}}) },
+
+			expectedSets: []Set{
+				{Name: "stack-3", Members: []string{}},
+				{Name: "stack-2", Members: []string{}},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "rename set to existing name",
+			mutate:   func() ([]Set, error) { return UpdateSet("stack-1", Set{Name: "stack-2", Members: []string{}}) },
+
+			expectedSets: nil,
+			expectErr:    true,
+		},
+		{
+			testName: "update unknown set",
+			mutate:   func() ([]Set, error) { return UpdateSet("stack-3", Set{Name: "stack-3", Members: []string{}}) },
+
+			expectedSets: nil,
+			expectErr:    true,
+		},
+		{
+			testName: "delete set",
+			mutate:   func() ([]Set, error) { return DeleteSet("stack-1") },
+
+			expectedSets: []Set{
+				{Name: "stack-2", Members: []string{}},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "delete unknown set",
+			mutate:   func() ([]Set, error) { return DeleteSet("stack-3") },
+
+			expectedSets: nil,
+			expectErr:    true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(initialDiskData)
+
+			s, err := testRun.mutate()
+
+			assert.Equal(t, testRun.expectedSets, s)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_SetProjects(t *testing.T) {
+	projects := []Project{
+		{ID: "1", Name: "api", Path: "./"},
+		{ID: "2", Name: "web", Path: "./"},
+	}
+
+	testRuns := []struct {
+		testName string
+		set      Set
+
+		expectedProjects []Project
+		expectErr        bool
+	}{
+		{
+			testName: "members in set's order",
+			set:      Set{Name: "stack", Members: []string{"2", "1"}},
+
+			expectedProjects: []Project{
+				{ID: "2", Name: "web", Path: "./"},
+				{ID: "1", Name: "api", Path: "./"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "unknown member",
+			set:      Set{Name: "stack", Members: []string{"1", "infra"}},
+
+			expectedProjects: nil,
+			expectErr:        true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			p, err := testRun.set.Projects(projects)
+
+			assert.Equal(t, testRun.expectedProjects, p)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marcantoineg/fileutil"
+)
+
+// A Problem is an issue found in an entry of the projects file.
+type Problem struct {
+	// kind of the entry, either "projects" or "sets"
+	Entry string
+
+	// index of the entry in the file
+	Index int
+
+	// JSON field of the entry the problem is about
+	Field string
+
+	Message string
+
+	// whether the entry can still be loaded despite the problem
+	Warning bool
+}
+
+// String returns the problem prefixed by the location of the field, e.g. "projects[2].path: ...".
+func (p Problem) String() string {
+	return fmt.Sprintf("%s[%d].%s: %s", p.Entry, p.Index, p.Field, p.Message)
+}
+
+// ValidationError lists every problem found in the projects file when at least one of them prevents loading it.
+type ValidationError struct {
+	Path     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "found %d problem(s) in '%s'\n", len(e.Problems), e.Path)
+	for _, p := range e.Problems {
+		if p.Warning {
+			fmt.Fprintf(&sb, "\n- %s (warning)", p)
+		} else {
+			fmt.Fprintf(&sb, "\n- %s", p)
+		}
+	}
+	return sb.String()
+}
+
+// Check reads the projects file and returns every problem found in it, in file order.
+// Unlike the other functions of the package, the file is not created, migrated nor saved.
+// If the file doesn't exist or can't be read or decoded, it returns the error as the second return value.
+func Check() ([]Problem, error) {
+	if !fileutil.Exists(getProjectsFilePath()) {
+		return nil, fmt.Errorf("projects file '%s' does not exist", getProjectsFilePath())
+	}
+
+	f, err := decodeProjectsFile()
+	if err != nil {
+		return nil, err
+	}
+	return f.validate(), nil
+}
+
+// validate returns every problem found in the file's projects and sets.
+func (f projectsFile) validate() []Problem {
+	var problems []Problem
+	projectProblem := func(i int, field string, message string, warning bool) {
+		problems = append(problems, Problem{Entry: "projects", Index: i, Field: field, Message: message, Warning: warning})
+	}
+
+	ids := map[string]bool{}
+	for i, p := range f.Projects {
+		if p.ID != "" && ids[p.ID] {
+			projectProblem(i, "id", fmt.Sprintf("duplicate project id '%s'", p.ID), false)
+		}
+		ids[p.ID] = true
+
+		if p.Name == "" {
+			projectProblem(i, "name", "the field is required", false)
+		}
+
+		if p.Path == "" {
+			projectProblem(i, "path", "the field is required", false)
+		} else if !p.IsRemote() && !fileutil.Exists(p.Path) {
+			projectProblem(i, "path", fmt.Sprintf("directory/file %s does not exist", p.Path), true)
+		}
+
+		for j, other := range f.Projects[:i] {
+			if p.Name != "" && other.Name == p.Name {
+				projectProblem(i, "name", fmt.Sprintf("same name as projects[%d]", j), true)
+				break
+			}
+		}
+
+		for j, other := range f.Projects[:i] {
+			if p.Path != "" && other.Path != "" && other.SamePath(p) {
+				projectProblem(i, "path", fmt.Sprintf("same path as projects[%d]", j), true)
+				break
+			}
+		}
+
+		if p.Group != NormalizeGroup(p.Group) {
+			projectProblem(i, "group", fmt.Sprintf("invalid group name '%s'", p.Group), false)
+		}
+
+		for j, c := range p.Commands {
+			if c.Name == "" {
+				projectProblem(i, fmt.Sprintf("commands[%d].name", j), "the field is required", false)
+			}
+			if c.Run == "" {
+				projectProblem(i, fmt.Sprintf("commands[%d].run", j), "the field is required", false)
+			}
+		}
+
+		if !p.IsRemote() {
+			for j, folder := range p.Folders {
+				if !fileutil.Exists(folder) {
+					projectProblem(i, fmt.Sprintf("folders[%d]", j), fmt.Sprintf("directory %s does not exist", folder), true)
+				}
+			}
+		}
+	}
+
+	for i, s := range f.Sets {
+		if s.Name == "" {
+			problems = append(problems, Problem{Entry: "sets", Index: i, Field: "name", Message: "the field is required"})
+		}
+
+		for j, member := range s.Members {
+			if !f.hasMember(member) {
+				problems = append(problems, Problem{Entry: "sets", Index: i, Field: fmt.Sprintf("members[%d]", j),
+					Message: fmt.Sprintf("unknown project '%s'", member), Warning: true})
+			}
+		}
+	}
+
+	return problems
+}
+
+// hasMember returns true if the set member references a project of the file, by ID or by name before being migrated.
+func (f projectsFile) hasMember(member string) bool {
+	for _, p := range f.Projects {
+		if p.ID == member || p.Name == member {
+			return true
+		}
+	}
+	return false
+}
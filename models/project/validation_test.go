@@ -0,0 +1,196 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/marcantoineg/fileutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Check(t *testing.T) {
+	testRuns := []struct {
+		testName        string
+		initialDiskData string
+
+		expectedProblems []Problem
+		expectErr        bool
+	}{
+		{
+			testName: "valid file",
+			initialDiskData: `
+			[
+				{
+					"id": "1",
+					"name": "example-project",
+					"path": "./"
+				}
+			]
+			`,
+
+			expectedProblems: nil,
+			expectErr:        false,
+		},
+		{
+			testName: "every problem is reported",
+			initialDiskData: `
+			{
+				"projects": [
+					{
+						"id": "1",
+						"path": "./"
+					},
+					{
+						"id": "1",
+						"name": "example-project-2",
+						"path": "not-a-valid-path",
+						"group": "Work//Client A"
+					},
+					{
+						"name": "example-project-3",
+						"folders": ["./", "not-a-valid-path"]
+					},
+					{
+						"name": "example-project-4",
+						"path": "/not/on/this/host",
+						"host": "dev-box",
+						"folders": ["/not/on/this/host"]
+					}
+				],
+				"sets": [
+					{
+						"members": ["1", "example-project-3", "unknown"]
+					}
+				]
+			}
+			`,
+
+			expectedProblems: []Problem{
+				{Entry: "projects", Index: 0, Field: "name", Message: "the field is required"},
+				{Entry: "projects", Index: 1, Field: "id", Message: "duplicate project id '1'"},
+				{Entry: "projects", Index: 1, Field: "path", Message: "directory/file not-a-valid-path does not exist", Warning: true},
+				{Entry: "projects", Index: 1, Field: "group", Message: "invalid group name 'Work//Client A'"},
+				{Entry: "projects", Index: 2, Field: "path", Message: "the field is required"},
+				{Entry: "projects", Index: 2, Field: "folders[1]", Message: "directory not-a-valid-path does not exist", Warning: true},
+				{Entry: "sets", Index: 0, Field: "name", Message: "the field is required"},
+				{Entry: "sets", Index: 0, Field: "members[2]", Message: "unknown project 'unknown'", Warning: true},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "duplicate names and paths",
+			initialDiskData: `
+			[
+				{
+					"id": "1",
+					"name": "api",
+					"path": "./"
+				},
+				{
+					"id": "2",
+					"name": "web",
+					"path": "."
+				},
+				{
+					"id": "3",
+					"name": "api",
+					"path": "~/code/api",
+					"host": "dev-box"
+				}
+			]
+			`,
+
+			expectedProblems: []Problem{
+				{Entry: "projects", Index: 1, Field: "path", Message: "same path as projects[0]", Warning: true},
+				{Entry: "projects", Index: 2, Field: "name", Message: "same name as projects[0]", Warning: true},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "incomplete commands",
+			initialDiskData: `
+			[
+				{
+					"id": "1",
+					"name": "api",
+					"path": "./",
+					"commands": [
+						{"name": "test", "run": "go test ./..."},
+						{"name": "lint"},
+						{"run": "make"}
+					]
+				}
+			]
+			`,
+
+			expectedProblems: []Problem{
+				{Entry: "projects", Index: 0, Field: "commands[1].run", Message: "the field is required"},
+				{Entry: "projects", Index: 0, Field: "commands[2].name", Message: "the field is required"},
+			},
+			expectErr: false,
+		},
+		{
+			testName:        "invalid object",
+			initialDiskData: `{"projects": "not-a-list"}`,
+
+			expectedProblems: nil,
+			expectErr:        true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			saveStringToFile(testRun.initialDiskData)
+
+			problems, err := Check()
+
+			assert.Equal(t, testRun.expectedProblems, problems)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_CheckMissingFile(t *testing.T) {
+	os.Remove(getProjectsFilePath())
+
+	problems, err := Check()
+
+	assert.Nil(t, problems)
+	assert.EqualError(t, err, fmt.Sprintf("projects file '%s' does not exist", getProjectsFilePath()))
+	assert.False(t, fileutil.Exists(getProjectsFilePath()))
+}
+
+func Test_ValidationError(t *testing.T) {
+	saveStringToFile(`
+	[
+		{
+			"name": "example-project-1"
+		},
+		{
+			"name": "example-project-2",
+			"path": "not-a-valid-path"
+		},
+		{
+			"path": "./"
+		}
+	]
+	`)
+
+	projects, err := GetAll()
+	assert.Nil(t, projects)
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []Problem{
+		{Entry: "projects", Index: 0, Field: "path", Message: "the field is required"},
+		{Entry: "projects", Index: 1, Field: "path", Message: "directory/file not-a-valid-path does not exist", Warning: true},
+		{Entry: "projects", Index: 2, Field: "name", Message: "the field is required"},
+	}, validationErr.Problems)
+	assert.Contains(t, err.Error(), "found 3 problem(s)")
+	assert.Contains(t, err.Error(), "- projects[1].path: directory/file not-a-valid-path does not exist (warning)")
+}
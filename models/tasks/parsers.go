@@ -0,0 +1,119 @@
+package tasks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// makeTargetRegexp matches the rule lines of a Makefile, capturing their targets.
+// Variable assignments (`:=`, `::=`) and double-colon rules are told apart once matched.
+var makeTargetRegexp = regexp.MustCompile(`^([^\s:=#][^:=#]*?)\s*::?([^=]|$)`)
+
+// parseMakefile returns the targets of the Makefile's rules, in file order.
+// Special targets like `.PHONY`, hidden targets starting with a dot and pattern rules are ignored.
+func parseMakefile(content []byte) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "\t") || strings.Contains(line, ":=") || strings.Contains(line, "::=") {
+			continue
+		}
+
+		match := makeTargetRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		for _, name := range strings.Fields(match[1]) {
+			if strings.HasPrefix(name, ".") || strings.ContainsAny(name, "%$") || slices.Contains(names, name) {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	return names, scanner.Err()
+}
+
+// parsePackageJSON returns the names of the package's scripts, sorted alphabetically.
+func parsePackageJSON(content []byte) ([]string, error) {
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// parseTaskfile returns the names of the Taskfile's tasks, in file order, internal tasks being ignored.
+func parseTaskfile(content []byte) ([]string, error) {
+	var taskfile struct {
+		Tasks yaml.Node `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal(content, &taskfile); err != nil {
+		return nil, err
+	}
+	if taskfile.Tasks.Kind == 0 {
+		return nil, nil
+	}
+	if taskfile.Tasks.Kind != yaml.MappingNode {
+		return nil, errors.New("tasks must be a mapping")
+	}
+
+	var names []string
+	for i := 0; i+1 < len(taskfile.Tasks.Content); i += 2 {
+		var task struct {
+			Internal bool `yaml:"internal"`
+		}
+		// tasks can be a single command or a list of commands instead of a mapping
+		if taskfile.Tasks.Content[i+1].Kind == yaml.MappingNode {
+			if err := taskfile.Tasks.Content[i+1].Decode(&task); err != nil {
+				return nil, err
+			}
+		}
+
+		if !task.Internal {
+			names = append(names, taskfile.Tasks.Content[i].Value)
+		}
+	}
+	return names, nil
+}
+
+// justRecipeRegexp matches the header line of a justfile recipe, capturing its name.
+var justRecipeRegexp = regexp.MustCompile(`^@?([A-Za-z_][A-Za-z0-9_-]*)(\s+[^:]*)?:([^=]|$)`)
+
+// justKeywords lists the keywords starting a justfile line which isn't a recipe.
+var justKeywords = []string{"alias", "export", "import", "mod", "set"}
+
+// parseJustfile returns the names of the justfile's recipes, in file order, private recipes starting with an underscore being ignored.
+func parseJustfile(content []byte) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if fields := strings.Fields(line); len(fields) > 0 && slices.Contains(justKeywords, fields[0]) {
+			continue
+		}
+
+		match := justRecipeRegexp.FindStringSubmatch(line)
+		if match == nil || strings.HasPrefix(match[1], "_") {
+			continue
+		}
+		names = append(names, match[1])
+	}
+	return names, scanner.Err()
+}
@@ -0,0 +1,145 @@
+// Package tasks implements the detection of the tasks defined for the task runners found in a project's directory.
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"ls-projects/models/launcher"
+)
+
+// Task runners supported.
+const (
+	Make = "make"
+	Npm  = "npm"
+	Task = "task"
+	Just = "just"
+)
+
+// A Target is a task defined for a task runner, e.g. a Makefile target or a package.json script.
+type Target struct {
+	// task runner running the target, one of Make, Npm, Task or Just
+	Runner string
+
+	Name string
+}
+
+// Command returns the command line running the target from the project's directory.
+// The target's name is quoted if it contains characters interpreted by the shell.
+func (t Target) Command() string {
+	name := t.Name
+	if strings.ContainsFunc(name, isShellSpecial) {
+		name = launcher.Quote(name)
+	}
+
+	if t.Runner == Npm {
+		return "npm run " + name
+	}
+	return t.Runner + " " + name
+}
+
+// isShellSpecial returns true if the rune must be quoted to be passed as is in a shell command line.
+func isShellSpecial(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.:/=+@,%", r)
+}
+
+// A detector reads the targets defined in a task runner's file.
+type detector struct {
+	runner string
+
+	// names of the files read by the detector, the first existing one being used
+	files []string
+
+	parse func(content []byte) ([]string, error)
+}
+
+var detectors = []detector{
+	{runner: Make, files: []string{"GNUmakefile", "makefile", "Makefile"}, parse: parseMakefile},
+	{runner: Npm, files: []string{"package.json"}, parse: parsePackageJSON},
+	{runner: Task, files: []string{"Taskfile.yml", "taskfile.yml", "Taskfile.yaml", "taskfile.yaml"}, parse: parseTaskfile},
+	{runner: Just, files: []string{"justfile", "Justfile", ".justfile"}, parse: parseJustfile},
+}
+
+// cacheEntry holds the targets detected in a directory along with the state of the files they were read from.
+type cacheEntry struct {
+	stamp   string
+	targets []Target
+	err     error
+}
+
+var cache = struct {
+	sync.Mutex
+	entries map[string]cacheEntry
+}{entries: map[string]cacheEntry{}}
+
+// Detect returns the targets of every task runner file found in the directory, grouped by runner.
+// Results are cached until one of the files is added, removed or modified.
+// A file that can't be read or parsed is skipped, the targets of the other files being returned
+// along with the errors as the second return value.
+func Detect(dir string) ([]Target, error) {
+	files, stamp := findFiles(dir)
+
+	cache.Lock()
+	entry, ok := cache.entries[dir]
+	cache.Unlock()
+	if ok && entry.stamp == stamp {
+		return entry.targets, entry.err
+	}
+
+	var targets []Target
+	var errs []error
+	for i, d := range detectors {
+		if files[i] == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(files[i])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		names, err := d.parse(content)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading tasks from '%s': %s", files[i], err))
+			continue
+		}
+
+		for _, name := range names {
+			targets = append(targets, Target{Runner: d.runner, Name: name})
+		}
+	}
+	err := errors.Join(errs...)
+
+	cache.Lock()
+	cache.entries[dir] = cacheEntry{stamp, targets, err}
+	cache.Unlock()
+
+	return targets, err
+}
+
+// findFiles returns the file found in the directory for each detector, an empty string if none exists,
+// along with a stamp changing whenever one of the files is added, removed or modified.
+func findFiles(dir string) ([]string, string) {
+	files := make([]string, len(detectors))
+	var stamp strings.Builder
+	for i, d := range detectors {
+		for _, name := range d.files {
+			path := filepath.Join(dir, name)
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue
+			}
+
+			files[i] = path
+			fmt.Fprintf(&stamp, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+			break
+		}
+	}
+	return files, stamp.String()
+}
@@ -0,0 +1,195 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Detect(t *testing.T) {
+	testRuns := []struct {
+		testName string
+		files    map[string]string
+
+		expectedTargets []Target
+		expectErr       bool
+	}{
+		{
+			testName: "no task runner",
+			files:    map[string]string{"README.md": "# example"},
+
+			expectedTargets: nil,
+			expectErr:       false,
+		},
+		{
+			testName: "Makefile",
+			files: map[string]string{"Makefile": `
+VERSION := 1.0
+BIN ?= app
+.PHONY: build test
+.DEFAULT_GOAL := build
+
+build: deps
+	go build -o $(BIN) -ldflags "-X main.version=$(VERSION)"
+
+# run the tests: every package
+test lint:: build
+	go test ./...
+
+%.o: %.c
+	cc -c $<
+
+deps:
+`},
+
+			expectedTargets: []Target{
+				{Runner: Make, Name: "build"},
+				{Runner: Make, Name: "test"},
+				{Runner: Make, Name: "lint"},
+				{Runner: Make, Name: "deps"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "package.json",
+			files:    map[string]string{"package.json": `{"name": "web", "scripts": {"test": "vitest", "dev": "vite", "build": "vite build"}}`},
+
+			expectedTargets: []Target{
+				{Runner: Npm, Name: "build"},
+				{Runner: Npm, Name: "dev"},
+				{Runner: Npm, Name: "test"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "Taskfile",
+			files: map[string]string{"Taskfile.yml": `
+version: '3'
+
+tasks:
+  build:
+    cmds:
+      - go build ./...
+  setup:
+    internal: true
+    cmds:
+      - go mod download
+  lint: golangci-lint run
+`},
+
+			expectedTargets: []Target{
+				{Runner: Task, Name: "build"},
+				{Runner: Task, Name: "lint"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "justfile",
+			files: map[string]string{"justfile": `
+set shell := ["bash", "-c"]
+version := "1.0"
+alias t := test
+
+# build the app: fast
+build target='debug':
+    cargo build --profile {{target}}
+
+@test: build
+    cargo test
+
+_private:
+    echo hidden
+`},
+
+			expectedTargets: []Target{
+				{Runner: Just, Name: "build"},
+				{Runner: Just, Name: "test"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "every task runner",
+			files: map[string]string{
+				"justfile":     "fmt:\n    cargo fmt\n",
+				"package.json": `{"scripts": {"dev": "vite"}}`,
+				"Makefile":     "all:\n\techo all\n",
+				"taskfile.yml": "tasks:\n  ci: task lint\n",
+			},
+
+			expectedTargets: []Target{
+				{Runner: Make, Name: "all"},
+				{Runner: Npm, Name: "dev"},
+				{Runner: Task, Name: "ci"},
+				{Runner: Just, Name: "fmt"},
+			},
+			expectErr: false,
+		},
+		{
+			testName: "invalid package.json",
+			files:    map[string]string{"package.json": `{"scripts": [`},
+
+			expectedTargets: nil,
+			expectErr:       true,
+		},
+		{
+			testName: "invalid package.json next to a Makefile",
+			files: map[string]string{
+				"package.json": `{"scripts": [`,
+				"Makefile":     "all:\n\techo all\n",
+			},
+
+			expectedTargets: []Target{{Runner: Make, Name: "all"}},
+			expectErr:       true,
+		},
+	}
+
+	for _, testRun := range testRuns {
+		t.Run(testRun.testName, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range testRun.files {
+				os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+			}
+
+			targets, err := Detect(dir)
+
+			assert.Equal(t, testRun.expectedTargets, targets)
+			if testRun.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func Test_DetectCache(t *testing.T) {
+	dir := t.TempDir()
+	makefile := filepath.Join(dir, "Makefile")
+	os.WriteFile(makefile, []byte("build:\n"), 0644)
+
+	targets, _ := Detect(dir)
+	assert.Equal(t, []Target{{Runner: Make, Name: "build"}}, targets)
+
+	cache.entries[dir] = cacheEntry{cache.entries[dir].stamp, []Target{{Runner: Make, Name: "cached"}}, nil}
+	targets, _ = Detect(dir)
+	assert.Equal(t, []Target{{Runner: Make, Name: "cached"}}, targets)
+
+	os.WriteFile(makefile, []byte("build:\ntest:\n"), 0644)
+	os.Chtimes(makefile, time.Now(), time.Now().Add(time.Second))
+	targets, _ = Detect(dir)
+	assert.Equal(t, []Target{{Runner: Make, Name: "build"}, {Runner: Make, Name: "test"}}, targets)
+}
+
+func Test_Command(t *testing.T) {
+	assert.Equal(t, "make build", Target{Runner: Make, Name: "build"}.Command())
+	assert.Equal(t, "npm run dev", Target{Runner: Npm, Name: "dev"}.Command())
+	assert.Equal(t, "task lint", Target{Runner: Task, Name: "lint"}.Command())
+	assert.Equal(t, "just fmt", Target{Runner: Just, Name: "fmt"}.Command())
+	assert.Equal(t, "npm run build:prod", Target{Runner: Npm, Name: "build:prod"}.Command())
+	assert.Equal(t, "make 'build all'", Target{Runner: Make, Name: "build all"}.Command())
+	assert.Equal(t, `just 'fmt; rm -rf ~'`, Target{Runner: Just, Name: "fmt; rm -rf ~"}.Command())
+	assert.Equal(t, `npm run 'it'\''s $HOME'`, Target{Runner: Npm, Name: "it's $HOME"}.Command())
+}